@@ -36,47 +36,166 @@ func main() {
 		Tools: []codegen.Tool{
 			{
 				Name:        "golang_list_packages",
-				Description: "Display a list of Go packages and their package comments. You can check the description and purpose of each package.",
-				InputSchema: struct{}{},
+				Description: "Display a list of Go packages and their package comments. You can check the description and purpose of each package, and the module it belongs to when several modules are served. Dependencies, including the standard library, can be listed as well and are marked as such. Every tool that takes a package name also accepts dependency packages.",
+				InputSchema: struct {
+					IncludeDependencies bool   `json:"include_dependencies,omitempty" jsonschema:"description=Whether to also list the dependencies of the loaded packages,default=false"`
+					OutputFormat        string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_search_docs",
+				Description: "Search the doc comments of all loaded Go packages with a natural-language query such as \"retry with backoff\". Matching symbols are ranked by relevance and returned with a snippet of their comment. You can find symbols by what they do when you don't know their names.",
+				InputSchema: struct {
+					Query        string `json:"query" jsonschema:"description=Words or phrase to search for in doc comments"`
+					Limit        int    `json:"limit,omitempty" jsonschema:"description=Maximum number of results,default=20"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
 			},
 			{
 				Name:        "golang_inspect_package",
-				Description: "List publicly available structs, methods, and functions in the specified Go package. You can check comments for each element.",
+				Description: "List publicly available constants, variables, functions, and types in the specified Go package, grouped the way go doc groups them: typed constants and variables, constructors, and methods are listed under their type. You can check comments for each element.",
 				InputSchema: struct {
 					PackageName     string `json:"package_name" jsonschema:"description=Package name"`
 					IncludeComments bool   `json:"include_comments,omitempty" jsonschema:"description=Whether to include comments,default=true"`
+					OutputFormat    string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_get_diagnostics",
+				Description: "List the errors reported while loading and type-checking Go packages. Documentation from a package with errors may be incomplete, so check here when a declaration is unexpectedly missing.",
+				InputSchema: struct {
+					PackageName         string `json:"package_name,omitempty" jsonschema:"description=Package to list the errors of. Defaults to all packages under the root directory"`
+					IncludeDependencies bool   `json:"include_dependencies,omitempty" jsonschema:"description=Whether to also list the errors of dependencies,default=false"`
+					OutputFormat        string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_search_symbols",
+				Description: "Search Go symbols by name across all loaded packages. Package-level identifiers, methods, and fields are matched by prefix, substring, or fuzzy match and ranked by match quality. You can discover symbols without knowing their exact package or name.",
+				InputSchema: struct {
+					Query        string `json:"query" jsonschema:"description=Name or part of the name to search for"`
+					Kind         string `json:"kind,omitempty" jsonschema:"description=Only return symbols of this kind,enum=func,enum=method,enum=struct,enum=interface,enum=type,enum=const,enum=var,enum=field"`
+					Limit        int    `json:"limit,omitempty" jsonschema:"description=Maximum number of results,default=20"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
 				}{},
 			},
 			{
 				Name:        "golang_get_struct_doc",
-				Description: "Display detailed information about the specified Go struct. You can check the struct's comments, fields, methods, and their comments.",
+				Description: "Display detailed information about the specified Go struct. You can check the struct's comments, fields with their struct tags, and method set. Embedded fields are flagged, and methods promoted from embedded fields are reported with the type they come from and whether they need a pointer receiver.",
+				InputSchema: struct {
+					PackageName   string `json:"package_name" jsonschema:"description=Package name where the struct is defined"`
+					StructName    string `json:"struct_name" jsonschema:"description=Name of the struct"`
+					IncludeLayout bool   `json:"include_layout,omitempty" jsonschema:"description=Whether to include the size and alignment of the struct and the offset of each field for the configured GOARCH,default=false"`
+					OutputFormat  string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_get_interface_doc",
+				Description: "Display detailed information about the specified Go interface. You can check the interface's comments, its method set including embedded interfaces, the type set of constraint interfaces, and the types that implement it.",
+				InputSchema: struct {
+					PackageName   string `json:"package_name" jsonschema:"description=Package name where the interface is defined"`
+					InterfaceName string `json:"interface_name" jsonschema:"description=Name of the interface"`
+					OutputFormat  string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_get_type_doc",
+				Description: "Display detailed information about the specified Go named type or type alias of any kind, such as `type Mode int`, `type HandlerFunc func(...)`, or `type IDs []string`. You can check the type's underlying type, comments, methods, constants of the type such as iota enumerations, and the functions that construct it.",
+				InputSchema: struct {
+					PackageName  string `json:"package_name" jsonschema:"description=Package name where the type is defined"`
+					TypeName     string `json:"type_name" jsonschema:"description=Name of the type"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_find_implementations",
+				Description: "Find implementation relationships of the specified Go type. For an interface, list the types that implement it. For any other type, list the interfaces it implements. Results include package paths and source positions.",
+				InputSchema: struct {
+					PackageName         string `json:"package_name" jsonschema:"description=Package name where the type is defined"`
+					TypeName            string `json:"type_name" jsonschema:"description=Name of the type or interface"`
+					IncludeDependencies bool   `json:"include_dependencies,omitempty" jsonschema:"description=Whether to also search the dependencies of the loaded packages,default=false"`
+					OutputFormat        string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_find_references",
+				Description: "Find every reference to the specified Go package-level symbol, method, or field in the loaded packages and their test files. Each reference includes its file, line, column, and enclosing function, so you can review the impact of a change without searching the text.",
+				InputSchema: struct {
+					PackageName  string `json:"package_name" jsonschema:"description=Package name where the symbol is defined"`
+					SymbolName   string `json:"symbol_name" jsonschema:"description=Name of the function or type or constant or variable. Use Type.Name for methods and fields"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_get_callers",
+				Description: "List the calls to the specified Go function or method, with call site positions. Callers of callers are followed up to the requested depth through the loaded packages. Calls through interfaces and function values are resolved with the selected call graph algorithm.",
 				InputSchema: struct {
-					PackageName string `json:"package_name" jsonschema:"description=Package name where the struct is defined"`
-					StructName  string `json:"struct_name" jsonschema:"description=Name of the struct"`
+					PackageName  string `json:"package_name" jsonschema:"description=Package name where the function is defined"`
+					FunctionName string `json:"function_name" jsonschema:"description=Name of the function. Use Type.Method for methods"`
+					Depth        int    `json:"depth,omitempty" jsonschema:"description=Number of caller levels to follow (up to 5),default=1"`
+					Algorithm    string `json:"algorithm,omitempty" jsonschema:"description=Call graph algorithm: static for static calls only; cha to resolve dynamic calls to every implementation; vta to resolve them to the types that flow to them,enum=static,enum=cha,enum=vta,default=cha"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_get_callees",
+				Description: "List the calls made by the specified Go function or method, with call site positions. Callees of callees are followed up to the requested depth through the loaded packages. Calls through interfaces and function values are resolved with the selected call graph algorithm.",
+				InputSchema: struct {
+					PackageName  string `json:"package_name" jsonschema:"description=Package name where the function is defined"`
+					FunctionName string `json:"function_name" jsonschema:"description=Name of the function. Use Type.Method for methods"`
+					Depth        int    `json:"depth,omitempty" jsonschema:"description=Number of callee levels to follow (up to 5),default=1"`
+					Algorithm    string `json:"algorithm,omitempty" jsonschema:"description=Call graph algorithm: static for static calls only; cha to resolve dynamic calls to every implementation; vta to resolve them to the types that flow to them,enum=static,enum=cha,enum=vta,default=cha"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_get_dependency_graph",
+				Description: "Display the import graph of a Go package, or of all loaded packages, as a Mermaid or DOT diagram, or as JSON. Import cycles and violations of the configured layering rules are reported, and with target_package the shortest import chain explains why the package depends on it.",
+				InputSchema: struct {
+					PackageName         string `json:"package_name,omitempty" jsonschema:"description=Package to start the graph from. Defaults to all packages under the root directory"`
+					Transitive          bool   `json:"transitive,omitempty" jsonschema:"description=Whether to follow imports transitively instead of listing direct imports only,default=false"`
+					IncludeDependencies bool   `json:"include_dependencies,omitempty" jsonschema:"description=Whether to include packages outside the root directory such as the standard library,default=false"`
+					TargetPackage       string `json:"target_package,omitempty" jsonschema:"description=Package to explain the dependency on with the shortest import chain from package_name"`
+					Diagram             string `json:"diagram,omitempty" jsonschema:"description=Diagram format of markdown results,enum=mermaid,enum=dot,default=mermaid"`
+					OutputFormat        string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
 				}{},
 			},
 			{
 				Name:        "golang_get_func_doc",
 				Description: "Display detailed information about the specified Go function. You can check the function's signature, comments, and usage examples.",
 				InputSchema: struct {
-					PackageName string `json:"package_name" jsonschema:"description=Package name where the function is defined"`
-					FuncName    string `json:"func_name" jsonschema:"description=Name of the function"`
+					PackageName  string `json:"package_name" jsonschema:"description=Package name where the function is defined"`
+					FuncName     string `json:"func_name" jsonschema:"description=Name of the function"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
 				}{},
 			},
 			{
 				Name:        "golang_get_method_doc",
 				Description: "Display detailed information about the specified Go struct method. You can check the method's signature, comments, and usage examples.",
 				InputSchema: struct {
-					PackageName string `json:"package_name" jsonschema:"description=Package name where the method is defined"`
-					StructName  string `json:"struct_name" jsonschema:"description=Name of the struct that owns the method"`
-					MethodName  string `json:"method_name" jsonschema:"description=Name of the method"`
+					PackageName  string `json:"package_name" jsonschema:"description=Package name where the method is defined"`
+					StructName   string `json:"struct_name" jsonschema:"description=Name of the struct that owns the method"`
+					MethodName   string `json:"method_name" jsonschema:"description=Name of the method"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
+				}{},
+			},
+			{
+				Name:        "golang_get_source",
+				Description: "Display the source code of the specified Go declaration with its file path and line range. Functions and methods are shown with their bodies, and constants and variables with their whole declaration block. You can read the implementation, not just the documentation.",
+				InputSchema: struct {
+					PackageName    string `json:"package_name" jsonschema:"description=Package name where the symbol is defined"`
+					SymbolName     string `json:"symbol_name" jsonschema:"description=Name of the function or type or constant or variable. Use Type.Name for methods and fields"`
+					ContextLines   int    `json:"context_lines,omitempty" jsonschema:"description=Number of surrounding lines to include before and after the declaration,default=0,minimum=0"`
+					IncludeComment bool   `json:"include_comment,omitempty" jsonschema:"description=Whether to include the doc comment,default=false"`
+					OutputFormat   string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
 				}{},
 			},
 			{
 				Name:        "golang_get_const_and_var_doc",
 				Description: "Display detailed information about constants and variables in the specified Go package. You can check the type, value, and comments for each constant and variable.",
 				InputSchema: struct {
-					PackageName string `json:"package_name" jsonschema:"description=Package name"`
+					PackageName  string `json:"package_name" jsonschema:"description=Package name"`
+					OutputFormat string `json:"output_format,omitempty" jsonschema:"description=Format of the result. JSON results are also returned as structured content. Defaults to the server setting,enum=markdown,enum=json"`
 				}{},
 			},
 		},
@@ -4,37 +4,160 @@ import (
 	"context"
 	"flag"
 	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	godoc "github.com/budougumi0617/godoc-mcp"
 	"github.com/budougumi0617/godoc-mcp/internal/config"
+	"github.com/budougumi0617/godoc-mcp/internal/diskcache"
 	"github.com/budougumi0617/godoc-mcp/internal/handler"
 	"github.com/budougumi0617/godoc-mcp/internal/parser"
+	"github.com/budougumi0617/godoc-mcp/internal/transport"
+	"github.com/budougumi0617/godoc-mcp/internal/watcher"
 	mcp "github.com/ktr0731/go-mcp"
 	jsonrpc2 "golang.org/x/exp/jsonrpc2"
 )
 
 func main() {
 	// Parse command line arguments
-	rootDir := flag.String("root", "", "Root directory path")
+	var rootDirs stringList
+	flag.Var(&rootDirs, "root", "Root directory path, repeated to serve several modules; a directory with a go.work serves the modules it uses")
+	watch := flag.Bool("watch", true, "Reload packages when source files change")
+	format := flag.String("output-format", string(handler.OutputFormatMarkdown), "Default format of tool results (markdown or json)")
+	layerRulesPath := flag.String("layer-rules", "", "Path of a JSON file with layering rules for dependency graphs")
+	transportName := flag.String("transport", transport.Stdio, "Transport to serve clients on (stdio, http or sse)")
+	addr := flag.String("addr", "localhost:8080", "Address to listen on with the http and sse transports")
+	lazy := flag.Bool("lazy", false, "List packages at startup and type-check each package on first use")
+	maxLocalPackages := flag.Int("max-local-packages", 0, "Maximum number of packages under the root directory kept type-checked per module in lazy mode, not counting their dependencies, 0 for no limit")
+	indexCache := flag.Bool("index-cache", true, "Cache the search indexes of packages on disk in lazy mode")
+	cacheDir := flag.String("cache-dir", "", "Directory of the index cache (default godoc-mcp in the user cache directory)")
 	flag.Parse()
 
+	switch *transportName {
+	case transport.Stdio, transport.StreamableHTTP, transport.SSE:
+	default:
+		log.Fatalf("Invalid transport: %s", *transportName)
+	}
+
+	outputFormat, err := handler.ParseOutputFormat(*format)
+	if err != nil {
+		log.Fatalf("Invalid output format: %v", err)
+	}
+
 	// Get configuration values
-	rootPath := config.GetRootDir(*rootDir)
+	roots := config.GetRootDirs(rootDirs)
+	moduleDirs, err := config.GetModuleDirs(roots)
+	if err != nil {
+		log.Fatalf("Failed to resolve root directories: %v", err)
+	}
+
+	// Load layering rules
+	var layerRules []config.LayerRule
+	if path := config.GetLayerRulesPath(*layerRulesPath); path != "" {
+		layerRules, err = config.LoadLayerRules(path)
+		if err != nil {
+			log.Fatalf("Failed to load layering rules: %v", err)
+		}
+	}
+
+	// Open the index cache, removing the entries that have not been used for a long time
+	opts := parser.Options{Lazy: *lazy, MaxLocalPackages: *maxLocalPackages}
+	if *lazy && *indexCache {
+		if dir := config.GetCacheDir(*cacheDir); dir != "" {
+			opts.Cache, err = diskcache.New(dir)
+			if err != nil {
+				log.Fatalf("Failed to open index cache: %v", err)
+			}
+			go func() {
+				if err := opts.Cache.Trim(diskcache.DefaultMaxAge); err != nil {
+					log.Printf("Failed to trim index cache: %v", err)
+				}
+			}()
+		}
+	}
 
-	// Initialize parser
-	p, err := parser.New(rootPath)
+	// Initialize a parser for each module
+	ws, err := parser.NewWorkspace(moduleDirs, opts)
 	if err != nil {
 		log.Fatalf("Failed to initialize parser: %v", err)
 	}
+	if diagnostics := ws.GetDiagnostics(false); len(diagnostics) > 0 {
+		log.Printf("%d packages have load errors, see golang_get_diagnostics", len(diagnostics))
+	}
+
+	ctx := context.Background()
+
+	// Watch source files and reload affected packages
+	watched := &watchers{ctx: ctx, ws: ws, running: make(map[string]*watcher.Watcher)}
+	if *watch {
+		if err := watched.watch(append(roots, moduleDirs...)); err != nil {
+			log.Fatalf("Failed to initialize watcher: %v", err)
+		}
+		defer watched.close()
+	}
 
 	// Initialize tool handler
-	toolHandler := handler.NewToolHandler(p)
+	toolHandler := handler.NewToolHandler(ws, outputFormat, layerRules)
 
 	// Create MCP handler
 	mcpHandler := godoc.NewHandler(toolHandler)
 
-	// Start MCP server
-	ctx, listener, binder := mcp.NewStdioTransport(context.Background(), mcpHandler, nil)
+	// Start MCP server, declaring output schemas and returning JSON results as structured content
+	var (
+		listener jsonrpc2.Listener
+		binder   jsonrpc2.Binder
+	)
+	if *transportName == transport.Stdio {
+		ctx, listener, binder = mcp.NewStdioTransport(ctx, mcpHandler, nil)
+	} else {
+		var httpListener *transport.Listener
+		ctx, httpListener, binder = transport.NewHTTPTransport(ctx, mcpHandler)
+		listener = httpListener
+
+		// Serve every client session of the HTTP transport from the same parser
+		mux := http.NewServeMux()
+		if *transportName == transport.StreamableHTTP {
+			mux.Handle("/mcp", httpListener.StreamableHTTPHandler())
+		} else {
+			mux.Handle("/sse", httpListener.SSEHandler("/message"))
+			mux.Handle("/message", httpListener.MessageHandler())
+		}
+		go func() {
+			log.Printf("Serving MCP over %s on %s", *transportName, *addr)
+			if err := http.ListenAndServe(*addr, mux); err != nil {
+				log.Fatalf("Failed to serve HTTP: %v", err)
+			}
+		}()
+	}
+	binder = handler.NewStructuredBinder(binder, mcpHandler.Tools)
+
+	// Without configured root directories, serve the modules under the roots of the clients
+	if !config.HasRootDirs(rootDirs) {
+		binder = handler.NewRootsBinder(binder, func(clientRoots []string) {
+			dirs, err := config.FindModuleDirs(clientRoots)
+			if err != nil {
+				log.Printf("Failed to find modules under client roots: %v", err)
+				return
+			}
+			if len(dirs) == 0 {
+				log.Printf("No Go modules found under client roots %v", clientRoots)
+				return
+			}
+			log.Printf("Loading %d modules under client roots", len(dirs))
+			if err := ws.SetModules(dirs); err != nil {
+				log.Printf("Failed to load modules: %v", err)
+				return
+			}
+			if *watch {
+				if err := watched.watch(append(clientRoots, dirs...)); err != nil {
+					log.Printf("Failed to watch modules: %v", err)
+				}
+			}
+		})
+	}
+
 	srv, err := jsonrpc2.Serve(ctx, listener, binder)
 	if err != nil {
 		log.Fatalf("Failed to start server: %v", err)
@@ -43,3 +166,100 @@ func main() {
 	// Wait for server
 	srv.Wait()
 }
+
+// stringList is a flag.Value collecting the values of a repeated flag.
+type stringList []string
+
+// String implements flag.Value.
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set implements flag.Value.
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// watchers watches the directories of the served modules and reloads their changed packages.
+type watchers struct {
+	ctx context.Context
+	ws  *parser.Workspace
+
+	mu      sync.Mutex
+	running map[string]*watcher.Watcher // Watchers by directory
+}
+
+// watch watches dirs, stopping the watchers of the directories that are no longer listed.
+func (w *watchers) watch(dirs []string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	keep := make(map[string]bool)
+	for _, dir := range watchDirs(dirs) {
+		keep[dir] = true
+		if _, ok := w.running[dir]; ok {
+			continue
+		}
+		dw, err := watcher.New(dir, watcher.DefaultDelay, func(files []string) {
+			if err := w.ws.Reload(files); err != nil {
+				log.Printf("Failed to reload packages: %v", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		w.running[dir] = dw
+
+		go func() {
+			if err := dw.Run(w.ctx); err != nil {
+				log.Printf("File watcher stopped: %v", err)
+			}
+		}()
+	}
+	for dir, dw := range w.running {
+		if !keep[dir] {
+			dw.Close()
+			delete(w.running, dir)
+		}
+	}
+	return nil
+}
+
+// close stops every watcher.
+func (w *watchers) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for dir, dw := range w.running {
+		dw.Close()
+		delete(w.running, dir)
+	}
+}
+
+// watchDirs returns the directories of dirs that are not under another one of them,
+// since watchers cover the subdirectories of their directory.
+func watchDirs(dirs []string) []string {
+	var result []string
+	for i, dir := range dirs {
+		dir, err := config.GetAbsPath(dir)
+		if err != nil {
+			continue
+		}
+		covered := false
+		for j, other := range dirs {
+			other, err := config.GetAbsPath(other)
+			if err != nil || (other == dir && j >= i) {
+				continue
+			}
+			if rel, err := filepath.Rel(other, dir); err == nil && !strings.HasPrefix(rel, "..") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result = append(result, dir)
+		}
+	}
+	return result
+}
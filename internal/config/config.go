@@ -1,33 +1,181 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
 )
 
 const (
 	// Environment variable names
-	EnvRootDir = "GODOC_MCP_ROOT_DIR"
+	EnvRootDir    = "GODOC_MCP_ROOT_DIR"
+	EnvLayerRules = "GODOC_MCP_LAYER_RULES"
+	EnvCacheDir   = "GODOC_MCP_CACHE_DIR"
 )
 
-// GetRootDir returns the root directory path.
+// LayerRule forbids the packages matching From from depending on the packages matching To.
+type LayerRule struct {
+	From   string `json:"from"`             // Pattern of the packages the rule applies to
+	To     string `json:"to"`               // Pattern of the packages they must not depend on
+	Reason string `json:"reason,omitempty"` // Why the dependency is forbidden
+}
+
+// layerRulesFile is the format of a layering rules file.
+type layerRulesFile struct {
+	Rules []LayerRule `json:"rules"`
+}
+
+// GetRootDirs returns the root directory paths.
 // Priority order:
-// 1. Command line argument
-// 2. Environment variable
+// 1. Command line arguments
+// 2. Environment variable, a list separated by the OS path list separator
 // 3. Current directory
-func GetRootDir(cmdRootDir string) string {
-	if cmdRootDir != "" {
-		return cmdRootDir
+func GetRootDirs(cmdRootDirs []string) []string {
+	if len(cmdRootDirs) > 0 {
+		return cmdRootDirs
 	}
 	if envRootDir := os.Getenv(EnvRootDir); envRootDir != "" {
-		return envRootDir
+		var dirs []string
+		for _, dir := range filepath.SplitList(envRootDir) {
+			if dir != "" {
+				dirs = append(dirs, dir)
+			}
+		}
+		if len(dirs) > 0 {
+			return dirs
+		}
 	}
 	// Get current directory
 	wd, err := os.Getwd()
 	if err != nil {
-		return "."
+		return []string{"."}
 	}
-	return wd
+	return []string{wd}
+}
+
+// HasRootDirs reports whether root directories are configured by command line arguments
+// or the environment variable, rather than defaulting to the current directory.
+func HasRootDirs(cmdRootDirs []string) bool {
+	return len(cmdRootDirs) > 0 || os.Getenv(EnvRootDir) != ""
+}
+
+// GetModuleDirs returns the absolute module directories of the root directories.
+// A root directory containing a go.work file is replaced by the modules the workspace uses.
+// Duplicate directories are removed.
+func GetModuleDirs(rootDirs []string) ([]string, error) {
+	return moduleDirs(rootDirs, func(root string) ([]string, error) {
+		return []string{root}, nil
+	})
+}
+
+// FindModuleDirs returns the absolute directories of the modules found under the root directories.
+// A root directory containing a go.work file is replaced by the modules the workspace uses,
+// and the others are searched for go.mod files, skipping hidden, vendor and testdata directories.
+// Duplicate directories are removed.
+func FindModuleDirs(rootDirs []string) ([]string, error) {
+	return moduleDirs(rootDirs, findModules)
+}
+
+// moduleDirs returns the absolute module directories of the root directories.
+// The modules of a root directory without a go.work file are listed by modules.
+func moduleDirs(rootDirs []string, modules func(root string) ([]string, error)) ([]string, error) {
+	var dirs []string
+	seen := make(map[string]bool)
+	add := func(dir string) error {
+		dir, err := GetAbsPath(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve root directory: %w", err)
+		}
+		dir = filepath.Clean(dir)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+		return nil
+	}
+
+	for _, root := range rootDirs {
+		uses, err := workspaceModules(root)
+		if err != nil {
+			return nil, err
+		}
+		if uses == nil {
+			if uses, err = modules(root); err != nil {
+				return nil, err
+			}
+		}
+		for _, dir := range uses {
+			if err := add(dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// findModules returns the directories containing a go.mod file under root, including root itself.
+func findModules(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped, but a missing root is an error
+			if path == root {
+				return err
+			}
+			return filepath.SkipDir
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == "go.mod" {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search modules in %s: %w", root, err)
+	}
+	return dirs, nil
+}
+
+// workspaceModules returns the module directories used by the go.work file in dir,
+// or nil if dir does not contain one.
+func workspaceModules(dir string) ([]string, error) {
+	path := filepath.Join(dir, "go.work")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+	work, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	dirs := make([]string, 0, len(work.Use))
+	for _, use := range work.Use {
+		moduleDir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(dir, moduleDir)
+		}
+		dirs = append(dirs, moduleDir)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("go.work in %s does not use any module", dir)
+	}
+	return dirs, nil
 }
 
 // GetAbsPath converts the specified path to an absolute path.
@@ -37,3 +185,51 @@ func GetAbsPath(path string) (string, error) {
 	}
 	return filepath.Abs(path)
 }
+
+// GetLayerRulesPath returns the path of the layering rules file, or an empty string if none is configured.
+// Priority order:
+// 1. Command line argument
+// 2. Environment variable
+func GetLayerRulesPath(cmdPath string) string {
+	if cmdPath != "" {
+		return cmdPath
+	}
+	return os.Getenv(EnvLayerRules)
+}
+
+// GetCacheDir returns the directory of the index cache, or an empty string if there is no user cache directory.
+// Priority order:
+// 1. Command line argument
+// 2. Environment variable
+// 3. godoc-mcp in the user cache directory
+func GetCacheDir(cmdCacheDir string) string {
+	if cmdCacheDir != "" {
+		return cmdCacheDir
+	}
+	if envCacheDir := os.Getenv(EnvCacheDir); envCacheDir != "" {
+		return envCacheDir
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(userCacheDir, "godoc-mcp")
+}
+
+// LoadLayerRules reads layering rules from a JSON file of the form {"rules": [{"from": ..., "to": ..., "reason": ...}]}.
+func LoadLayerRules(path string) ([]LayerRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layering rules: %w", err)
+	}
+	var file layerRulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse layering rules: %w", err)
+	}
+	for i, rule := range file.Rules {
+		if rule.From == "" || rule.To == "" {
+			return nil, fmt.Errorf("layering rule %d must have both from and to", i+1)
+		}
+	}
+	return file.Rules, nil
+}
@@ -3,34 +3,36 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
-func TestGetRootDir(t *testing.T) {
+func TestGetRootDirs(t *testing.T) {
 	currentDir, err := os.Getwd()
 	if err != nil {
 		t.Fatalf("os.Getwd() error = %v", err)
 	}
 
 	tests := map[string]struct {
-		cmdRootDir string
-		envRootDir string
-		want       string
+		cmdRootDirs []string
+		envRootDir  string
+		want        []string
 	}{
-		"Command line argument takes precedence": {
-			cmdRootDir: "/path/to/root",
-			envRootDir: "/path/to/env",
-			want:       "/path/to/root",
+		"Command line arguments take precedence": {
+			cmdRootDirs: []string{"/path/to/root", "/path/to/other"},
+			envRootDir:  "/path/to/env",
+			want:        []string{"/path/to/root", "/path/to/other"},
 		},
 		"Environment variable is used": {
-			cmdRootDir: "",
 			envRootDir: "/path/to/env",
-			want:       "/path/to/env",
+			want:       []string{"/path/to/env"},
+		},
+		"Environment variable lists several directories": {
+			envRootDir: "/path/to/env" + string(filepath.ListSeparator) + "/path/to/other",
+			want:       []string{"/path/to/env", "/path/to/other"},
 		},
 		"Default value is used": {
-			cmdRootDir: "",
-			envRootDir: "",
-			want:       currentDir,
+			want: []string{currentDir},
 		},
 	}
 
@@ -42,14 +44,70 @@ func TestGetRootDir(t *testing.T) {
 				t.Setenv(EnvRootDir, tt.envRootDir)
 			}
 
-			got := GetRootDir(tt.cmdRootDir)
-			if got != tt.want {
-				t.Errorf("GetRootDir() = %q, want %q", got, tt.want)
+			got := GetRootDirs(tt.cmdRootDirs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRootDirs() = %q, want %q", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestGetCacheDir(t *testing.T) {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Skipf("os.UserCacheDir() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		cmdCacheDir string
+		envCacheDir string
+		want        string
+	}{
+		"Command line argument takes precedence": {
+			cmdCacheDir: "/path/to/cache",
+			envCacheDir: "/path/to/env",
+			want:        "/path/to/cache",
+		},
+		"Environment variable is used": {
+			envCacheDir: "/path/to/env",
+			want:        "/path/to/env",
+		},
+		"Default value is used": {
+			want: filepath.Join(userCacheDir, "godoc-mcp"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnvCacheDir, tt.envCacheDir)
+
+			if got := GetCacheDir(tt.cmdCacheDir); got != tt.want {
+				t.Errorf("GetCacheDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetModuleDirs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	work := "go 1.24\n\nuse (\n\t./api\n\t./tools/lint\n)\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.work"), []byte(work), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	other := t.TempDir()
+
+	got, err := GetModuleDirs([]string{dir, other, filepath.Join(dir, "api")})
+	if err != nil {
+		t.Fatalf("GetModuleDirs() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "api"), filepath.Join(dir, "tools", "lint"), other}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetModuleDirs() = %q, want %q", got, want)
+	}
+}
+
 func TestGetAbsPath(t *testing.T) {
 	t.Parallel()
 	dir, err := os.Getwd()
@@ -85,3 +143,74 @@ func TestGetAbsPath(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadLayerRules(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content string
+		want    []LayerRule
+		wantErr bool
+	}{
+		"rules": {
+			content: `{"rules": [{"from": "internal/model", "to": "internal/handler/...", "reason": "models are independent"}]}`,
+			want: []LayerRule{
+				{From: "internal/model", To: "internal/handler/...", Reason: "models are independent"},
+			},
+		},
+		"missing pattern": {
+			content: `{"rules": [{"from": "internal/model"}]}`,
+			wantErr: true,
+		},
+		"invalid JSON": {
+			content: `rules:`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "layers.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("os.WriteFile() error = %v", err)
+			}
+			got, err := LoadLayerRules(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLayerRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadLayerRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindModuleDirs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"go.mod", "services/api/go.mod", "services/api/testdata/fixture/go.mod", ".cache/mod/go.mod", "vendor/example.com/lib/go.mod"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("os.MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte("module example.com/m\n"), 0o644); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+
+	got, err := FindModuleDirs([]string{dir})
+	if err != nil {
+		t.Fatalf("FindModuleDirs() error = %v", err)
+	}
+	want := []string{dir, filepath.Join(dir, "services", "api")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindModuleDirs() = %q, want %q", got, want)
+	}
+
+	if _, err := FindModuleDirs([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Errorf("FindModuleDirs() of a missing directory error = nil, want an error")
+	}
+}
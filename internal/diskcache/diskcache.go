@@ -0,0 +1,124 @@
+// Package diskcache stores JSON values in files of a directory, keyed by hashes of their inputs.
+package diskcache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultMaxAge is the time after which unused entries are removed by Trim.
+const DefaultMaxAge = 30 * 24 * time.Hour
+
+// Cache is a directory of cached values.
+// A Cache is safe for concurrent use, including by several processes sharing the directory.
+type Cache struct {
+	dir string
+}
+
+// New creates a Cache storing values in dir, creating the directory if needed.
+func New(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Dir returns the directory of the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Key returns a key identifying the concatenation of parts.
+// Parts are length-prefixed, so that moving bytes from one part to the next changes the key.
+func Key(parts ...[]byte) string {
+	h := sha256.New()
+	var size [8]byte
+	for _, part := range parts {
+		binary.BigEndian.PutUint64(size[:], uint64(len(part)))
+		h.Write(size[:])
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// path returns the file of the entry with the specified key.
+// Entries are spread over subdirectories named after the first byte of their key.
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// Get decodes the value stored with key into v, and reports whether it was found.
+// Entries that cannot be decoded are reported as not found.
+func (c *Cache) Get(key string, v any) bool {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false
+	}
+	// Record the use, so that Trim keeps the entry
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return true
+}
+
+// Put stores v with key.
+// The entry is written to a temporary file first, so that readers never see a partial entry.
+func (c *Cache) Put(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Trim removes the entries that were not stored or read for longer than maxAge.
+func (c *Cache) Trim(maxAge time.Duration) error {
+	cutoff := time.Now().Add(-maxAge)
+	return filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (!strings.HasSuffix(path, ".json") && !strings.HasSuffix(path, ".tmp")) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().Before(cutoff) {
+			os.Remove(path)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,100 @@
+package diskcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type entry struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(filepath.Join(t.TempDir(), "cache"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	key := Key([]byte("example.com/m"), []byte("content"))
+	var got entry
+	if c.Get(key, &got) {
+		t.Fatalf("Get() of a missing key = true, want false")
+	}
+
+	want := entry{Name: "a", Count: 2}
+	if err := c.Put(key, want); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if !c.Get(key, &got) {
+		t.Fatalf("Get() of a stored key = false, want true")
+	}
+	if got != want {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	// Corrupted entries are not found
+	if err := os.WriteFile(c.path(key), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if c.Get(key, &got) {
+		t.Errorf("Get() of a corrupted entry = true, want false")
+	}
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		a, b [][]byte
+		same bool
+	}{
+		"same parts":       {a: [][]byte{[]byte("a"), []byte("b")}, b: [][]byte{[]byte("a"), []byte("b")}, same: true},
+		"different parts":  {a: [][]byte{[]byte("a"), []byte("b")}, b: [][]byte{[]byte("a"), []byte("c")}, same: false},
+		"moved boundaries": {a: [][]byte{[]byte("ab"), []byte("c")}, b: [][]byte{[]byte("a"), []byte("bc")}, same: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Key(tt.a...) == Key(tt.b...); got != tt.same {
+				t.Errorf("Key(%q) == Key(%q) = %v, want %v", tt.a, tt.b, got, tt.same)
+			}
+		})
+	}
+}
+
+func TestCache_Trim(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	oldKey, newKey := Key([]byte("old")), Key([]byte("new"))
+	for _, key := range []string{oldKey, newKey} {
+		if err := c.Put(key, entry{Name: key}); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(c.path(oldKey), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Trim(time.Hour); err != nil {
+		t.Fatalf("Trim() error = %v", err)
+	}
+	var got entry
+	if c.Get(oldKey, &got) {
+		t.Errorf("Get() of an unused entry after Trim() = true, want false")
+	}
+	if !c.Get(newKey, &got) {
+		t.Errorf("Get() of a recent entry after Trim() = false, want true")
+	}
+}
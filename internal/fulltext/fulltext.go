@@ -0,0 +1,217 @@
+// Package fulltext provides an in-memory inverted index for searching natural-language text.
+package fulltext
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Result represents a document matching a query
+type Result struct {
+	ID    int     // Document ID returned by Add
+	Score float64 // BM25 score, higher is better
+}
+
+// posting represents the occurrences of a term in a document.
+type posting struct {
+	id   int
+	freq int
+}
+
+// Index is an inverted index ranking documents with BM25.
+// An Index is not safe for concurrent modification, but may be searched concurrently
+// once all documents have been added.
+type Index struct {
+	postings map[string][]posting
+	docLens  []int
+	totalLen int
+}
+
+// NewIndex creates an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string][]posting),
+	}
+}
+
+// Add indexes text as a new document and returns its ID.
+// IDs are assigned sequentially starting from 0.
+func (idx *Index) Add(text string) int {
+	id := len(idx.docLens)
+	terms := Terms(text)
+
+	freqs := make(map[string]int)
+	for _, term := range terms {
+		freqs[term]++
+	}
+	for term, freq := range freqs {
+		idx.postings[term] = append(idx.postings[term], posting{id: id, freq: freq})
+	}
+	idx.docLens = append(idx.docLens, len(terms))
+	idx.totalLen += len(terms)
+
+	return id
+}
+
+// Len returns the number of indexed documents.
+func (idx *Index) Len() int {
+	return len(idx.docLens)
+}
+
+// Search returns the documents matching any term of query, ranked by BM25.
+// At most limit results are returned if limit is positive.
+func (idx *Index) Search(query string, limit int) []Result {
+	n := len(idx.docLens)
+	if n == 0 {
+		return nil
+	}
+	avgLen := float64(idx.totalLen) / float64(n)
+
+	scores := make(map[int]float64)
+	seen := make(map[string]bool)
+	for _, term := range Terms(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := idx.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (float64(n)-df+0.5)/(df+0.5))
+		for _, p := range postings {
+			tf := float64(p.freq)
+			norm := tf * (k1 + 1) / (tf + k1*(1-b+b*float64(idx.docLens[p.id])/avgLen))
+			scores[p.id] += idf * norm
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, Result{ID: id, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Snippet returns the sentence of text that contains the most terms of query.
+// The first sentence is returned if no sentence contains a query term.
+func Snippet(text, query string) string {
+	sentences := splitSentences(text)
+	if len(sentences) == 0 {
+		return ""
+	}
+
+	want := make(map[string]bool)
+	for _, term := range Terms(query) {
+		want[term] = true
+	}
+
+	best, bestCount := 0, 0
+	for i, sentence := range sentences {
+		count := 0
+		for _, term := range Terms(sentence) {
+			if want[term] {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+	return sentences[best]
+}
+
+// splitSentences splits text into sentences, joining wrapped lines.
+func splitSentences(text string) []string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	var sentences []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '.' && text[i] != '!' && text[i] != '?' {
+			continue
+		}
+		if i+1 < len(text) && text[i+1] != ' ' {
+			continue
+		}
+		sentences = append(sentences, strings.TrimSpace(text[start:i+1]))
+		start = i + 1
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// Terms splits text into normalized index terms.
+// Words are split at non-alphanumeric characters and camelCase boundaries,
+// lowercased and stemmed, and stop words are removed.
+func Terms(text string) []string {
+	var terms []string
+	for _, word := range splitWords(text) {
+		word = strings.ToLower(word)
+		if len(word) < 2 || stopWords[word] {
+			continue
+		}
+		terms = append(terms, Stem(word))
+	}
+	return terms
+}
+
+// splitWords splits text at non-alphanumeric characters and camelCase boundaries.
+func splitWords(text string) []string {
+	var words []string
+	runes := []rune(text)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		// Split "fooBar" before "B" and "HTTPServer" before "S"
+		prev := runes[i-1]
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || (unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// stopWords are common English words that are not indexed.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "has": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "of": true, "on": true, "or": true, "that": true,
+	"the": true, "this": true, "to": true, "was": true, "when": true, "which": true,
+	"will": true, "with": true,
+}
@@ -0,0 +1,119 @@
+package fulltext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]string{
+		"retri": {"retry", "retries", "retried", "retrying"},
+		"load":  {"load", "loads", "loaded", "loading"},
+		"pars":  {"parse", "parses", "parsed", "parsing"},
+		"run":   {"run", "runs", "running"},
+		"cach":  {"cache", "caches", "cached", "caching"},
+		"class": {"class", "classes"},
+	}
+
+	for want, words := range tests {
+		for _, word := range words {
+			t.Run(word, func(t *testing.T) {
+				t.Parallel()
+				if got := Stem(word); got != want {
+					t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		text string
+		want []string
+	}{
+		"sentence with stop words": {
+			text: "Retries the request with backoff.",
+			want: []string{"retri", "request", "backoff"},
+		},
+		"camel case identifiers": {
+			text: "NewHTTPServer parseJSON",
+			want: []string{"new", "http", "server", "pars", "json"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := Terms(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Terms(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex()
+	docs := []string{
+		"Do sends an HTTP request and returns an HTTP response.",
+		"Retry calls fn until it succeeds, waiting with exponential backoff between retries.",
+		"Backoff returns the delay before the next attempt.",
+		"Close closes the connection.",
+	}
+	for _, doc := range docs {
+		idx.Add(doc)
+	}
+
+	tests := map[string]struct {
+		query string
+		limit int
+		want  []int
+	}{
+		"documents matching more terms rank higher": {
+			query: "retry with backoff",
+			want:  []int{1, 2},
+		},
+		"stemmed terms match": {
+			query: "retrying requests",
+			want:  []int{1, 0},
+		},
+		"limited": {
+			query: "retry with backoff",
+			limit: 1,
+			want:  []int{1},
+		},
+		"no match": {
+			query: "database",
+			want:  []int{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := []int{}
+			for _, r := range idx.Search(tt.query, tt.limit) {
+				got = append(got, r.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	t.Parallel()
+
+	text := "Client is an HTTP client.\nIt retries failed requests\nwith backoff. It is safe for concurrent use."
+	want := "It retries failed requests with backoff."
+	if got := Snippet(text, "retry backoff"); got != want {
+		t.Errorf("Snippet() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package fulltext
+
+import "strings"
+
+// derivationalSuffixes are replaced in order, the first matching suffix wins.
+var derivationalSuffixes = []struct {
+	suffix      string
+	replacement string
+}{
+	{"ational", "ate"},
+	{"ization", "ize"},
+	{"fulness", "ful"},
+	{"iveness", "ive"},
+	{"ation", "ate"},
+	{"ness", ""},
+	{"ment", ""},
+	{"ful", ""},
+	{"ly", ""},
+}
+
+// Stem reduces an English word to its stem with a light suffix-stripping stemmer.
+// It is not a complete Porter stemmer, but maps the common inflections of a word,
+// such as "retry", "retries", "retried" and "retrying", to the same stem.
+// word must be lowercase.
+func Stem(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+
+	// Plurals
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ies"):
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+	case strings.HasSuffix(word, "s"):
+		word = word[:len(word)-1]
+	}
+
+	// Verb forms
+	for _, suffix := range []string{"ingly", "edly", "ing", "ed"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || len(stem) < 3 || !hasVowel(stem) {
+			continue
+		}
+		word = undouble(stem)
+		break
+	}
+
+	// Derivational suffixes
+	for _, s := range derivationalSuffixes {
+		stem, ok := strings.CutSuffix(word, s.suffix)
+		if ok && len(stem) >= 3 && hasVowel(stem) {
+			word = stem + s.replacement
+			break
+		}
+	}
+
+	// Normalize the endings left by the steps above
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 3 && !isVowel(word[len(word)-2]):
+		word = word[:len(word)-1] + "i"
+	case strings.HasSuffix(word, "e") && len(word) > 4:
+		word = word[:len(word)-1]
+	}
+
+	return word
+}
+
+// undouble removes the last letter of a word ending in a double consonant, except l, s and z.
+func undouble(word string) string {
+	n := len(word)
+	if n < 2 || word[n-1] != word[n-2] || isVowel(word[n-1]) {
+		return word
+	}
+	switch word[n-1] {
+	case 'l', 's', 'z':
+		return word
+	}
+	return word[:n-1]
+}
+
+// hasVowel reports whether word contains a vowel.
+func hasVowel(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if isVowel(word[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVowel reports whether c is a lowercase vowel.
+func isVowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
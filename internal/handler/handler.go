@@ -3,255 +3,883 @@ package handler
 import (
 	"context"
 	"fmt"
-	"go/types"
-	"strings"
 
 	godoc "github.com/budougumi0617/godoc-mcp"
+	"github.com/budougumi0617/godoc-mcp/internal/config"
 	"github.com/budougumi0617/godoc-mcp/internal/model"
 	"github.com/budougumi0617/godoc-mcp/internal/parser"
 	mcp "github.com/ktr0731/go-mcp"
 )
 
+// defaultSearchLimit is the number of search results returned when the request does not specify a limit.
+const defaultSearchLimit = 20
+
+// Depth limits of call graph queries
+const (
+	defaultCallDepth = 1 // Depth used when the request does not specify one
+	maxCallDepth     = 5 // Deepest level followed, to keep results readable
+)
+
+// OutputFormat is the format of tool results.
+type OutputFormat string
+
+const (
+	OutputFormatMarkdown OutputFormat = "markdown" // Markdown for reading
+	OutputFormatJSON     OutputFormat = "json"     // JSON for programmatic use
+)
+
+// ParseOutputFormat parses the name of an output format.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch f := OutputFormat(s); f {
+	case OutputFormatMarkdown, OutputFormatJSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format: %s", s)
+}
+
+// checkOutputFormat returns an error if a tool call requests an unknown output format.
+func checkOutputFormat(requested string) error {
+	if requested == "" {
+		return nil
+	}
+	_, err := ParseOutputFormat(requested)
+	return err
+}
+
+// resolveOutputFormat returns the output format requested by a tool call, or the default format if none is requested.
+// Unknown formats are rejected by checkOutputFormat before any result is formatted.
+func resolveOutputFormat(requested string, defaultFormat OutputFormat) OutputFormat {
+	if f, err := ParseOutputFormat(requested); err == nil {
+		return f
+	}
+	return defaultFormat
+}
+
 // ToolHandler is a handler structure that processes MCP tool requests.
 type ToolHandler struct {
-	parser *parser.Parser
+	workspace    *parser.Workspace
+	outputFormat OutputFormat
+	layerRules   []parser.LayerRule
 }
 
-// NewToolHandler creates a new ToolHandler instance.
-func NewToolHandler(p *parser.Parser) *ToolHandler {
+// NewToolHandler creates a new ToolHandler instance serving the packages of the modules in w.
+// Results are returned in outputFormat unless a request specifies another format.
+// Dependency graphs report the dependencies forbidden by layerRules.
+func NewToolHandler(w *parser.Workspace, outputFormat OutputFormat, layerRules []config.LayerRule) *ToolHandler {
 	return &ToolHandler{
-		parser: p,
+		workspace:    w,
+		outputFormat: outputFormat,
+		layerRules:   toLayerRules(layerRules),
+	}
+}
+
+// parserFor returns the parser of the module serving the package at pkgPath.
+func (h *ToolHandler) parserFor(pkgPath string) *parser.Parser {
+	return h.workspace.Parser(pkgPath)
+}
+
+// isJSON reports whether results should be returned as JSON for the requested output format.
+func (h *ToolHandler) isJSON(requested string) bool {
+	return resolveOutputFormat(requested, h.outputFormat) == OutputFormatJSON
+}
+
+// textResult returns a tool result consisting of text.
+func textResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.CallToolContent{
+			mcp.TextContent{Text: text},
+		},
+	}
+}
+
+// packageResult returns the text result of a tool answering from the package at pkgPath.
+// A warning is added if the package has load errors, since the answer may then be incomplete.
+func (h *ToolHandler) packageResult(pkgPath, text string) *mcp.CallToolResult {
+	result := textResult(text)
+	if errs := h.parserFor(pkgPath).GetPackageErrors(pkgPath); len(errs) > 0 {
+		result.Content = append(result.Content, mcp.TextContent{Text: model.FormatLoadWarning(pkgPath, toPackageErrorDocs(errs))})
 	}
+	return result
+}
+
+// packageError adds the load errors of the package at pkgPath to err,
+// since declarations that are not found are often missing because of them.
+func (h *ToolHandler) packageError(pkgPath string, err error) error {
+	if errs := h.parserFor(pkgPath).GetPackageErrors(pkgPath); len(errs) > 0 {
+		return fmt.Errorf("%w\n\n%s", err, model.FormatLoadWarning(pkgPath, toPackageErrorDocs(errs)))
+	}
+	return err
 }
 
 // HandleToolGolangListPackages returns a list of all loaded packages.
+// Dependencies of the loaded packages are included if requested.
 func (h *ToolHandler) HandleToolGolangListPackages(ctx context.Context, req *godoc.ToolGolangListPackagesRequest) (*mcp.CallToolResult, error) {
-	pkgs := h.parser.GetAllPackages()
-	if len(pkgs) == 0 {
-		return &mcp.CallToolResult{
-			Content: []mcp.CallToolContent{
-				mcp.TextContent{Text: "No packages loaded."},
-			},
-		}, nil
-	}
-
-	var packages []model.PackageInfo
-	for _, p := range pkgs {
-		// Get package comment
-		packages = append(packages, model.PackageInfo{
-			Name:       p.Name,
-			ImportPath: p.PkgPath,
-			Comment:    parser.GetPackageComment(p),
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	packages := make([]model.PackageInfo, 0)
+	for _, mp := range h.workspace.Parsers() {
+		for _, p := range mp.ListPackages() {
+			// Get package comment
+			packages = append(packages, model.PackageInfo{
+				Name:       p.Name,
+				ImportPath: p.PkgPath,
+				Module:     mp.ModulePath(),
+				Comment:    parser.GetPackageComment(p),
+				ErrorCount: len(p.Errors),
+			})
+		}
+	}
+	if len(packages) == 0 && !h.isJSON(req.OutputFormat) {
+		setStructured(ctx, model.FormatPackageList(packages))
+		return textResult("No packages loaded."), nil
+	}
+
+	if req.IncludeDependencies {
+		for _, p := range h.workspace.GetDependencyPackages() {
+			packages = append(packages, model.PackageInfo{
+				Name:         p.Name,
+				ImportPath:   p.PkgPath,
+				Comment:      parser.GetPackageComment(p),
+				IsDependency: true,
+				ErrorCount:   len(p.Errors),
+			})
+		}
+	}
+
+	// Format in the requested output format
+	data := model.FormatPackageList(packages)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return textResult(data), nil
+	}
+	return textResult(model.FormatPackageListMarkdown(packages)), nil
+}
+
+// HandleToolGolangGetDiagnostics returns the errors reported while loading packages.
+func (h *ToolHandler) HandleToolGolangGetDiagnostics(ctx context.Context, req *godoc.ToolGolangGetDiagnosticsRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	var diagnostics []parser.PackageDiagnostics
+	if req.PackageName != "" {
+		if _, err := h.parserFor(req.PackageName).GetPackage(req.PackageName); err != nil {
+			return nil, fmt.Errorf("failed to get package: %w", err)
+		}
+		if errs := h.parserFor(req.PackageName).GetPackageErrors(req.PackageName); len(errs) > 0 {
+			diagnostics = append(diagnostics, parser.PackageDiagnostics{PkgPath: req.PackageName, IsDependency: !h.parserFor(req.PackageName).IsLocal(req.PackageName), Errors: errs})
+		}
+	} else {
+		diagnostics = h.workspace.GetDiagnostics(req.IncludeDependencies)
+	}
+
+	// Convert package errors
+	packages := make([]model.PackageDiagnosticsDoc, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		packages = append(packages, model.PackageDiagnosticsDoc{
+			ImportPath:   d.PkgPath,
+			IsDependency: d.IsDependency,
+			Errors:       toPackageErrorDocs(d.Errors),
 		})
 	}
 
-	// Format in markdown
-	mdContent := model.FormatPackageListMarkdown(packages)
+	// Format in the requested output format
+	data := model.FormatDiagnostics(packages)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return textResult(data), nil
+	}
+	return textResult(model.FormatDiagnosticsMarkdown(packages)), nil
+}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.CallToolContent{
-			mcp.TextContent{Text: mdContent},
-		},
-	}, nil
+// toPackageErrorDocs converts package errors from the parser.
+func toPackageErrorDocs(errs []parser.PackageError) []model.PackageErrorDoc {
+	docs := make([]model.PackageErrorDoc, 0, len(errs))
+	for _, e := range errs {
+		docs = append(docs, model.PackageErrorDoc{
+			Kind:     e.Kind,
+			Position: e.Position,
+			Message:  e.Message,
+		})
+	}
+	return docs
 }
 
-// HandleToolGolangInspectPackage lists exported structs, methods, and functions in the specified package.
+// HandleToolGolangInspectPackage lists the exported declarations in the specified package, grouped under their types.
 func (h *ToolHandler) HandleToolGolangInspectPackage(ctx context.Context, req *godoc.ToolGolangInspectPackageRequest) (*mcp.CallToolResult, error) {
-	pkg, err := h.parser.GetPackage(req.PackageName)
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	pkg, err := h.parserFor(req.PackageName).GetPackage(req.PackageName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get package: %w", err)
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get package: %w", err))
 	}
 
 	// Create package info
 	pkgInfo := model.PackageInfo{
-		Name:       pkg.Name,
-		ImportPath: pkg.PkgPath,
-		Comment:    parser.GetPackageComment(pkg),
+		Name:         pkg.Name,
+		ImportPath:   pkg.PkgPath,
+		Comment:      parser.GetPackageComment(pkg),
+		IsDependency: !h.workspace.IsLocal(pkg.PkgPath),
 	}
 
-	// Collect struct, function, and method information
-	scope := pkg.Types.Scope()
-	var structs []model.StructSummary
-	var funcs []model.FuncSummary
-	var methods []model.MethodSummary
+	// Collect declarations grouped under their types
+	pkgDoc, err := h.parserFor(req.PackageName).GetPackageDoc(req.PackageName)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get package documentation: %w", err))
+	}
+	namedTypes := make([]model.TypeSummary, 0, len(pkgDoc.Types))
+	for _, t := range pkgDoc.Types {
+		namedTypes = append(namedTypes, model.TypeSummary{
+			Name:         t.Name,
+			Kind:         t.Kind,
+			Comment:      t.Comment,
+			Constants:    toValueSummaries(t.Consts),
+			Variables:    toValueSummaries(t.Vars),
+			Constructors: toFuncSummaries(t.Constructors),
+			Methods:      toFuncSummaries(t.Methods),
+		})
+	}
+	constants := toValueSummaries(pkgDoc.Consts)
+	variables := toValueSummaries(pkgDoc.Vars)
+	funcs := toFuncSummaries(pkgDoc.Funcs)
 
-	for _, name := range scope.Names() {
-		obj := scope.Lookup(name)
-		if !obj.Exported() {
-			continue
-		}
+	examples := toExamples(h.parserFor(req.PackageName).GetExamples(pkg, ""))
 
-		switch obj := obj.(type) {
-		case *types.TypeName:
-			// Get type definition
-			if _, ok := obj.Type().Underlying().(*types.Struct); ok {
-				structs = append(structs, model.StructSummary{
-					Name:    obj.Name(),
-					Comment: parser.GetComment(pkg, obj), // Use public function from parser package
-				})
-			}
-		case *types.Func:
-			sig, ok := obj.Type().(*types.Signature)
-			if !ok {
-				continue
-			}
+	// Format in the requested output format
+	data := model.FormatPackageInspection(pkgInfo, constants, variables, funcs, namedTypes, examples, req.IncludeComments)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatPackageInspectionMarkdown(pkgInfo, constants, variables, funcs, namedTypes, examples, req.IncludeComments)), nil
+}
 
-			// For methods (with receiver)
-			if sig.Recv() != nil {
-				recvType := sig.Recv().Type().String()
-				// Remove * for pointer types
-				recvType = strings.TrimPrefix(recvType, "*")
-				methods = append(methods, model.MethodSummary{
-					ReceiverType: recvType,
-					Name:         obj.Name(),
-					Comment:      parser.GetComment(pkg, obj), // Use public function from parser package
-				})
-			} else {
-				// For functions
-				funcs = append(funcs, model.FuncSummary{
-					Name:    obj.Name(),
-					Comment: parser.GetComment(pkg, obj), // Use public function from parser package
-				})
-			}
-		}
+// HandleToolGolangSearchSymbols searches symbols by name across all loaded packages.
+func (h *ToolHandler) HandleToolGolangSearchSymbols(ctx context.Context, req *godoc.ToolGolangSearchSymbolsRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	found := h.workspace.SearchSymbols(req.Query, req.Kind, limit)
+
+	// Convert symbol information
+	symbols := make([]model.SymbolDoc, 0, len(found))
+	for _, s := range found {
+		symbols = append(symbols, model.SymbolDoc{
+			Name:       s.Name,
+			Kind:       s.Kind,
+			ImportPath: s.PkgPath,
+			Signature:  s.Signature,
+			Summary:    s.Summary,
+			Position:   s.Position,
+		})
 	}
 
-	// Format in markdown
-	mdContent := model.FormatPackageInspectionMarkdown(pkgInfo, structs, funcs, methods, req.IncludeComments)
+	// Format in the requested output format
+	data := model.FormatSearchSymbols(req.Query, symbols)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return textResult(data), nil
+	}
+	return textResult(model.FormatSearchSymbolsMarkdown(req.Query, symbols)), nil
+}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.CallToolContent{
-			mcp.TextContent{Text: mdContent},
-		},
-	}, nil
+// HandleToolGolangSearchDocs searches the doc comments of all loaded packages.
+func (h *ToolHandler) HandleToolGolangSearchDocs(ctx context.Context, req *godoc.ToolGolangSearchDocsRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	found := h.workspace.SearchDocs(req.Query, limit)
+
+	// Convert match information
+	matches := make([]model.DocMatchDoc, 0, len(found))
+	for _, m := range found {
+		matches = append(matches, model.DocMatchDoc{
+			Name:       m.Name,
+			Kind:       m.Kind,
+			ImportPath: m.PkgPath,
+			Snippet:    m.Snippet,
+			Position:   m.Position,
+			Score:      m.Score,
+		})
+	}
+
+	// Format in the requested output format
+	data := model.FormatSearchDocs(req.Query, matches)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return textResult(data), nil
+	}
+	return textResult(model.FormatSearchDocsMarkdown(req.Query, matches)), nil
 }
 
 // HandleToolGolangGetStructDoc returns information about the specified struct.
 func (h *ToolHandler) HandleToolGolangGetStructDoc(ctx context.Context, req *godoc.ToolGolangGetStructDocRequest) (*mcp.CallToolResult, error) {
-	structInfo, err := h.parser.GetStructInfo(req.PackageName, req.StructName)
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	structInfo, err := h.parserFor(req.PackageName).GetStructInfo(req.PackageName, req.StructName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get struct info: %w", err)
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get struct info: %w", err))
 	}
 
 	// Convert field and method information
-	var fields []model.FieldDoc
-	var methods []model.MethodDoc
-
+	fields := make([]model.FieldDoc, 0, len(structInfo.Fields))
 	for _, f := range structInfo.Fields {
-		fields = append(fields, model.FieldDoc{
+		field := model.FieldDoc{
 			Name:       f.Name,
 			Type:       f.Type,
 			Comment:    f.Comment,
 			IsExported: f.IsExported,
+			Embedded:   f.Embedded,
+			Tag:        f.Tag,
+			Tags:       make([]model.StructTagDoc, 0, len(f.Tags)),
+		}
+		for _, tag := range f.Tags {
+			field.Tags = append(field.Tags, model.StructTagDoc{
+				Key:     tag.Key,
+				Value:   tag.Value,
+				Name:    tag.Name,
+				Options: tag.Options,
+			})
+		}
+		if req.IncludeLayout && f.Layout != nil {
+			field.Layout = &model.FieldLayoutDoc{
+				Offset: f.Layout.Offset,
+				Size:   f.Layout.Size,
+				Align:  f.Layout.Align,
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	methods := toMethodDocs(structInfo.Methods)
+
+	// The layout is only reported on request
+	var layout *model.StructLayoutDoc
+	if req.IncludeLayout && structInfo.Layout != nil {
+		layout = &model.StructLayoutDoc{
+			Arch:  structInfo.Layout.Arch,
+			Size:  structInfo.Layout.Size,
+			Align: structInfo.Layout.Align,
+		}
+	}
+
+	// Format in the requested output format
+	data := model.FormatStructDoc(structInfo.Name, structInfo.Comment, structInfo.Declaration, fields, methods, layout, toExamples(structInfo.Examples), toTypeParamDocs(structInfo.TypeParams), toInstantiationDocs(structInfo.Instantiations))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatStructDocMarkdown(structInfo.Name, structInfo.Comment, structInfo.Declaration, fields, methods, layout, toExamples(structInfo.Examples), toTypeParamDocs(structInfo.TypeParams), toInstantiationDocs(structInfo.Instantiations))), nil
+}
+
+// HandleToolGolangGetInterfaceDoc returns information about the specified interface.
+func (h *ToolHandler) HandleToolGolangGetInterfaceDoc(ctx context.Context, req *godoc.ToolGolangGetInterfaceDocRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	interfaceInfo, err := h.parserFor(req.PackageName).GetInterfaceInfo(req.PackageName, req.InterfaceName)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get interface info: %w", err))
+	}
+
+	// Convert method information
+	methods := make([]model.InterfaceMethodDoc, 0, len(interfaceInfo.Methods))
+
+	for _, m := range interfaceInfo.Methods {
+		methods = append(methods, model.InterfaceMethodDoc{
+			Name:         m.Name,
+			Signature:    m.Signature,
+			Comment:      m.Comment,
+			EmbeddedFrom: m.EmbeddedFrom,
 		})
 	}
 
-	for _, m := range structInfo.Methods {
-		methods = append(methods, model.MethodDoc{
-			Name:      m.Name,
-			Signature: m.Signature,
-			Comment:   m.Comment,
+	// Empty lists are encoded as empty JSON arrays rather than null
+	embeddeds := append([]string{}, interfaceInfo.Embeddeds...)
+	typeSet := append([]string{}, interfaceInfo.TypeSet...)
+	implementations := toImplementationDocs(interfaceInfo.Implementations)
+	typeParams := toTypeParamDocs(interfaceInfo.TypeParams)
+	instantiations := toInstantiationDocs(interfaceInfo.Instantiations)
+
+	// Format in the requested output format
+	data := model.FormatInterfaceDoc(interfaceInfo.Name, interfaceInfo.Comment, interfaceInfo.Declaration, methods, embeddeds, typeSet, interfaceInfo.IsConstraint, implementations, toExamples(interfaceInfo.Examples), typeParams, instantiations)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatInterfaceDocMarkdown(interfaceInfo.Name, interfaceInfo.Comment, interfaceInfo.Declaration, methods, embeddeds, typeSet, interfaceInfo.IsConstraint, implementations, toExamples(interfaceInfo.Examples), typeParams, instantiations)), nil
+}
+
+// HandleToolGolangGetTypeDoc returns information about the specified named type or type alias.
+func (h *ToolHandler) HandleToolGolangGetTypeDoc(ctx context.Context, req *godoc.ToolGolangGetTypeDocRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	typeInfo, err := h.parserFor(req.PackageName).GetTypeInfo(req.PackageName, req.TypeName)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get type info: %w", err))
+	}
+
+	// Convert constructor and method information
+	constructors := make([]model.ConstructorDoc, 0, len(typeInfo.Constructors))
+	for _, c := range typeInfo.Constructors {
+		constructors = append(constructors, model.ConstructorDoc{
+			Name:      c.Name,
+			Signature: c.Signature,
+			Comment:   c.Comment,
 		})
 	}
+	methods := toMethodDocs(typeInfo.Methods)
+	constants := toConstDocs(typeInfo.Constants)
+	examples := toExamples(typeInfo.Examples)
+
+	// Format in the requested output format
+	data := model.FormatTypeDoc(typeInfo.Name, typeInfo.Kind, typeInfo.Comment, typeInfo.Declaration, typeInfo.Underlying, constants, constructors, methods, examples, toTypeParamDocs(typeInfo.TypeParams))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatTypeDocMarkdown(typeInfo.Name, typeInfo.Kind, typeInfo.Comment, typeInfo.Declaration, typeInfo.Underlying, constants, constructors, methods, examples, toTypeParamDocs(typeInfo.TypeParams))), nil
+}
 
-	// Format in markdown
-	mdContent := model.FormatStructDocMarkdown(structInfo.Name, structInfo.Comment, fields, methods)
+// HandleToolGolangFindImplementations returns the implementation relationships of the specified type.
+func (h *ToolHandler) HandleToolGolangFindImplementations(ctx context.Context, req *godoc.ToolGolangFindImplementationsRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.CallToolContent{
-			mcp.TextContent{Text: mdContent},
-		},
-	}, nil
+	implementsInfo, err := h.parserFor(req.PackageName).GetImplementsInfo(req.PackageName, req.TypeName, req.IncludeDependencies)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get implementation info: %w", err))
+	}
+
+	// Format in the requested output format
+	data := model.FormatImplementations(implementsInfo.Name, implementsInfo.PkgPath, implementsInfo.IsInterface, toImplementationDocs(implementsInfo.Implementations), toImplementationDocs(implementsInfo.Interfaces))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatImplementationsMarkdown(implementsInfo.Name, implementsInfo.PkgPath, implementsInfo.IsInterface, toImplementationDocs(implementsInfo.Implementations), toImplementationDocs(implementsInfo.Interfaces))), nil
 }
 
-// HandleToolGolangGetFuncDoc returns information about the specified function.
-func (h *ToolHandler) HandleToolGolangGetFuncDoc(ctx context.Context, req *godoc.ToolGolangGetFuncDocRequest) (*mcp.CallToolResult, error) {
-	funcInfo, err := h.parser.GetFuncInfo(req.PackageName, req.FuncName)
+// HandleToolGolangFindReferences returns the references to the specified symbol.
+func (h *ToolHandler) HandleToolGolangFindReferences(ctx context.Context, req *godoc.ToolGolangFindReferencesRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	refsInfo, err := h.parserFor(req.PackageName).FindReferences(req.PackageName, req.SymbolName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get function info: %w", err)
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to find references: %w", err))
+	}
+
+	// Format in the requested output format
+	data := model.FormatReferences(refsInfo.Name, refsInfo.Kind, refsInfo.PkgPath, refsInfo.Position, toReferenceDocs(refsInfo.References))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
 	}
+	return h.packageResult(req.PackageName, model.FormatReferencesMarkdown(refsInfo.Name, refsInfo.Kind, refsInfo.PkgPath, refsInfo.Position, toReferenceDocs(refsInfo.References))), nil
+}
 
-	// Convert examples
-	var examples []model.Example
-	for _, e := range funcInfo.Examples {
-		examples = append(examples, model.Example{
-			Name:   e.Name,
-			Code:   e.Code,
-			Output: e.Output,
+// toReferenceDocs converts references from the parser.
+func toReferenceDocs(references []parser.Reference) []model.ReferenceDoc {
+	docs := make([]model.ReferenceDoc, 0, len(references))
+	for _, r := range references {
+		docs = append(docs, model.ReferenceDoc{
+			ImportPath: r.PkgPath,
+			File:       r.File,
+			Line:       r.Line,
+			Column:     r.Column,
+			Enclosing:  r.Enclosing,
 		})
 	}
+	return docs
+}
 
-	// Format in markdown
-	mdContent := model.FormatFuncDocMarkdown(funcInfo.Name, funcInfo.Signature, funcInfo.Comment, examples)
+// HandleToolGolangGetDependencyGraph returns the import graph of a package or of all loaded packages.
+func (h *ToolHandler) HandleToolGolangGetDependencyGraph(ctx context.Context, req *godoc.ToolGolangGetDependencyGraphRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.CallToolContent{
-			mcp.TextContent{Text: mdContent},
-		},
-	}, nil
+	graph, err := h.parserFor(req.PackageName).GetImportGraph(req.PackageName, req.Transitive, req.IncludeDependencies, h.layerRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency graph: %w", err)
+	}
+
+	// Explain why the package depends on the target package
+	var why *model.DependencyPathDoc
+	if req.TargetPackage != "" {
+		if req.PackageName == "" {
+			return nil, fmt.Errorf("package_name is required to explain a dependency on %s", req.TargetPackage)
+		}
+		path, err := h.parserFor(req.PackageName).FindImportPath(req.PackageName, req.TargetPackage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find import path: %w", err)
+		}
+		if path == nil {
+			path = []string{}
+		}
+		why = &model.DependencyPathDoc{From: req.PackageName, To: req.TargetPackage, Path: path}
+	}
+
+	// Format in the requested output format
+	data := model.FormatDependencyGraph(req.PackageName, req.Transitive, toGraphPackageDocs(graph.Packages), toImportEdgeDocs(graph.Imports), graph.Cycles, why, toLayerViolationDocs(graph.Violations))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return textResult(data), nil
+	}
+	return textResult(model.FormatDependencyGraphMarkdown(req.PackageName, req.Transitive, toGraphPackageDocs(graph.Packages), toImportEdgeDocs(graph.Imports), graph.Cycles, why, toLayerViolationDocs(graph.Violations), req.Diagram)), nil
+}
+
+// toGraphPackageDocs converts import graph packages from the parser.
+func toGraphPackageDocs(packages []parser.GraphPackage) []model.GraphPackageDoc {
+	docs := make([]model.GraphPackageDoc, 0, len(packages))
+	for _, pkg := range packages {
+		docs = append(docs, model.GraphPackageDoc{
+			ImportPath:   pkg.Path,
+			IsDependency: pkg.IsDependency,
+		})
+	}
+	return docs
+}
+
+// toImportEdgeDocs converts imports from the parser.
+func toImportEdgeDocs(imports []parser.ImportEdge) []model.ImportEdgeDoc {
+	docs := make([]model.ImportEdgeDoc, 0, len(imports))
+	for _, edge := range imports {
+		docs = append(docs, model.ImportEdgeDoc{
+			From: edge.From,
+			To:   edge.To,
+		})
+	}
+	return docs
+}
+
+// toLayerViolationDocs converts layering violations from the parser.
+func toLayerViolationDocs(violations []parser.LayerViolation) []model.LayerViolationDoc {
+	docs := make([]model.LayerViolationDoc, 0, len(violations))
+	for _, v := range violations {
+		docs = append(docs, model.LayerViolationDoc{
+			From:     v.Rule.From,
+			To:       v.Rule.To,
+			Reason:   v.Rule.Reason,
+			Package:  v.Package,
+			Imported: v.Imported,
+			Path:     v.Path,
+		})
+	}
+	return docs
+}
+
+// toLayerRules converts layering rules from the configuration.
+func toLayerRules(rules []config.LayerRule) []parser.LayerRule {
+	converted := make([]parser.LayerRule, 0, len(rules))
+	for _, rule := range rules {
+		converted = append(converted, parser.LayerRule{
+			From:   rule.From,
+			To:     rule.To,
+			Reason: rule.Reason,
+		})
+	}
+	return converted
+}
+
+// HandleToolGolangGetCallers returns the callers of the specified function or method.
+func (h *ToolHandler) HandleToolGolangGetCallers(ctx context.Context, req *godoc.ToolGolangGetCallersRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	depth, algorithm := callGraphQuery(req.Depth, req.Algorithm)
+	calls, err := h.parserFor(req.PackageName).GetCallers(req.PackageName, req.FunctionName, algorithm, depth)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get callers: %w", err))
+	}
+
+	// Format in the requested output format
+	data := model.FormatCallers(req.FunctionName, req.PackageName, algorithm, depth, toCallDocs(calls))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatCallersMarkdown(req.FunctionName, req.PackageName, algorithm, depth, toCallDocs(calls))), nil
+}
+
+// HandleToolGolangGetCallees returns the callees of the specified function or method.
+func (h *ToolHandler) HandleToolGolangGetCallees(ctx context.Context, req *godoc.ToolGolangGetCalleesRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	depth, algorithm := callGraphQuery(req.Depth, req.Algorithm)
+	calls, err := h.parserFor(req.PackageName).GetCallees(req.PackageName, req.FunctionName, algorithm, depth)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get callees: %w", err))
+	}
+
+	// Format in the requested output format
+	data := model.FormatCallees(req.FunctionName, req.PackageName, algorithm, depth, toCallDocs(calls))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatCalleesMarkdown(req.FunctionName, req.PackageName, algorithm, depth, toCallDocs(calls))), nil
+}
+
+// callGraphQuery returns the depth and algorithm of a call graph query, applying defaults and limits.
+func callGraphQuery(depth int, algorithm string) (int, string) {
+	if depth <= 0 {
+		depth = defaultCallDepth
+	}
+	if depth > maxCallDepth {
+		depth = maxCallDepth
+	}
+	if algorithm == "" {
+		algorithm = parser.CallGraphCHA
+	}
+	return depth, algorithm
+}
+
+// toCallDocs converts calls from the parser.
+func toCallDocs(calls []parser.Call) []model.CallDoc {
+	docs := make([]model.CallDoc, 0, len(calls))
+	for _, c := range calls {
+		docs = append(docs, model.CallDoc{
+			Caller:   c.Caller,
+			Callee:   c.Callee,
+			Position: c.Position,
+			Dynamic:  c.Dynamic,
+			Depth:    c.Depth,
+		})
+	}
+	return docs
+}
+
+// toImplementationDocs converts implementation relationships from the parser.
+func toImplementationDocs(implementations []parser.Implementation) []model.ImplementationDoc {
+	docs := make([]model.ImplementationDoc, 0, len(implementations))
+	for _, impl := range implementations {
+		docs = append(docs, model.ImplementationDoc{
+			Name:       impl.Name,
+			ImportPath: impl.PkgPath,
+			Position:   impl.Position,
+			IsPointer:  impl.IsPointer,
+		})
+	}
+	return docs
+}
+
+// toMethodDocs converts methods from the parser.
+func toMethodDocs(methods []parser.Method) []model.MethodDoc {
+	docs := make([]model.MethodDoc, 0, len(methods))
+	for _, m := range methods {
+		docs = append(docs, model.MethodDoc{
+			Name:            m.Name,
+			Signature:       m.Signature,
+			Comment:         m.Comment,
+			PromotedFrom:    m.PromotedFrom,
+			PointerReceiver: m.PointerReceiver,
+		})
+	}
+	return docs
+}
+
+// toValueSummaries converts constant and variable groups from the parser.
+func toValueSummaries(groups []parser.ValueGroup) []model.ValueSummary {
+	summaries := make([]model.ValueSummary, 0, len(groups))
+	for _, g := range groups {
+		summaries = append(summaries, model.ValueSummary{
+			Names:   g.Names,
+			Comment: g.Comment,
+		})
+	}
+	return summaries
+}
+
+// toFuncSummaries converts functions and methods from the parser.
+func toFuncSummaries(funcs []parser.FuncEntry) []model.FuncSummary {
+	summaries := make([]model.FuncSummary, 0, len(funcs))
+	for _, f := range funcs {
+		summaries = append(summaries, model.FuncSummary{
+			Name:    f.Name,
+			Comment: f.Comment,
+		})
+	}
+	return summaries
+}
+
+// toConstDocs converts constants from the parser.
+func toConstDocs(constants []parser.ConstInfo) []model.ConstDoc {
+	docs := make([]model.ConstDoc, 0, len(constants))
+	for _, c := range constants {
+		docs = append(docs, model.ConstDoc{
+			Name:        c.Name,
+			Type:        c.Type,
+			Value:       c.Value,
+			Comment:     c.Comment,
+			Declaration: c.Declaration,
+		})
+	}
+	return docs
+}
+
+// toExamples converts examples from the parser.
+func toExamples(examples []parser.Example) []model.Example {
+	result := make([]model.Example, 0, len(examples))
+	for _, e := range examples {
+		result = append(result, model.Example{
+			Name:      e.Name,
+			Code:      e.Code,
+			Output:    e.Output,
+			Unordered: e.Unordered,
+		})
+	}
+	return result
+}
+
+// toTypeParamDocs converts type parameters from the parser.
+func toTypeParamDocs(typeParams []parser.TypeParam) []model.TypeParamDoc {
+	var docs []model.TypeParamDoc
+	for _, tp := range typeParams {
+		docs = append(docs, model.TypeParamDoc{
+			Name:       tp.Name,
+			Constraint: tp.Constraint,
+			TypeSet:    tp.TypeSet,
+		})
+	}
+	return docs
+}
+
+// toInstantiationDocs converts instantiations from the parser.
+func toInstantiationDocs(instantiations []parser.Instantiation) []model.InstantiationDoc {
+	var docs []model.InstantiationDoc
+	for _, inst := range instantiations {
+		docs = append(docs, model.InstantiationDoc{
+			TypeArgs: inst.TypeArgs,
+			Type:     inst.Type,
+			Position: inst.Position,
+			Count:    inst.Count,
+		})
+	}
+	return docs
+}
+
+// HandleToolGolangGetFuncDoc returns information about the specified function.
+func (h *ToolHandler) HandleToolGolangGetFuncDoc(ctx context.Context, req *godoc.ToolGolangGetFuncDocRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	funcInfo, err := h.parserFor(req.PackageName).GetFuncInfo(req.PackageName, req.FuncName)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get function info: %w", err))
+	}
+
+	examples := toExamples(funcInfo.Examples)
+
+	// Format in the requested output format
+	data := model.FormatFuncDoc(funcInfo.Name, funcInfo.Signature, funcInfo.Comment, examples, toTypeParamDocs(funcInfo.TypeParams), toInstantiationDocs(funcInfo.Instantiations))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatFuncDocMarkdown(funcInfo.Name, funcInfo.Signature, funcInfo.Comment, examples, toTypeParamDocs(funcInfo.TypeParams), toInstantiationDocs(funcInfo.Instantiations))), nil
 }
 
 // HandleToolGolangGetMethodDoc returns information about the specified method of a struct.
 func (h *ToolHandler) HandleToolGolangGetMethodDoc(ctx context.Context, req *godoc.ToolGolangGetMethodDocRequest) (*mcp.CallToolResult, error) {
-	methodInfo, err := h.parser.GetMethodInfo(req.PackageName, req.StructName, req.MethodName)
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	methodInfo, err := h.parserFor(req.PackageName).GetMethodInfo(req.PackageName, req.StructName, req.MethodName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get method info: %w", err)
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get method info: %w", err))
 	}
 
-	// Convert examples
-	var examples []model.Example
-	for _, e := range methodInfo.Examples {
-		examples = append(examples, model.Example{
-			Name:   e.Name,
-			Code:   e.Code,
-			Output: e.Output,
-		})
+	examples := toExamples(methodInfo.Examples)
+
+	// Format in the requested output format
+	data := model.FormatMethodDoc(req.StructName, methodInfo.Name, methodInfo.Signature, methodInfo.Comment, examples, toTypeParamDocs(methodInfo.TypeParams))
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
 	}
+	return h.packageResult(req.PackageName, model.FormatMethodDocMarkdown(req.StructName, methodInfo.Name, methodInfo.Signature, methodInfo.Comment, examples, toTypeParamDocs(methodInfo.TypeParams))), nil
+}
 
-	// Format in markdown
-	mdContent := model.FormatMethodDocMarkdown(req.StructName, methodInfo.Name, methodInfo.Signature, methodInfo.Comment, examples)
+// HandleToolGolangGetSource returns the source code of the specified declaration.
+func (h *ToolHandler) HandleToolGolangGetSource(ctx context.Context, req *godoc.ToolGolangGetSourceRequest) (*mcp.CallToolResult, error) {
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+	if req.ContextLines < 0 {
+		return nil, fmt.Errorf("context_lines must not be negative: %d", req.ContextLines)
+	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.CallToolContent{
-			mcp.TextContent{Text: mdContent},
-		},
-	}, nil
+	sourceInfo, err := h.parserFor(req.PackageName).GetSourceInfo(req.PackageName, req.SymbolName, req.ContextLines, req.IncludeComment)
+	if err != nil {
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get source: %w", err))
+	}
+
+	// Format in the requested output format
+	data := model.FormatSource(sourceInfo.Name, sourceInfo.Kind, sourceInfo.PkgPath, sourceInfo.File, sourceInfo.StartLine, sourceInfo.EndLine, sourceInfo.Source)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatSourceMarkdown(sourceInfo.Name, sourceInfo.Kind, sourceInfo.PkgPath, sourceInfo.File, sourceInfo.StartLine, sourceInfo.EndLine, sourceInfo.Source)), nil
 }
 
 // HandleToolGolangGetConstAndVarDoc returns information about constants and variables in the specified package.
 func (h *ToolHandler) HandleToolGolangGetConstAndVarDoc(ctx context.Context, req *godoc.ToolGolangGetConstAndVarDocRequest) (*mcp.CallToolResult, error) {
-	constInfos, varInfos, err := h.parser.GetConstAndVarInfo(req.PackageName)
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	constInfos, varInfos, err := h.parserFor(req.PackageName).GetConstAndVarInfo(req.PackageName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get constant and variable info: %w", err)
+		return nil, h.packageError(req.PackageName, fmt.Errorf("failed to get constant and variable info: %w", err))
 	}
 
 	// Convert constant and variable information
-	var constants []model.ConstDoc
-	var variables []model.VarDoc
-
-	for _, c := range constInfos {
-		constants = append(constants, model.ConstDoc{
-			Name:    c.Name,
-			Type:    c.Type,
-			Value:   c.Value,
-			Comment: c.Comment,
-		})
-	}
+	constants := toConstDocs(constInfos)
+	variables := make([]model.VarDoc, 0, len(varInfos))
 
 	for _, v := range varInfos {
 		variables = append(variables, model.VarDoc{
-			Name:    v.Name,
-			Type:    v.Type,
-			Comment: v.Comment,
+			Name:        v.Name,
+			Type:        v.Type,
+			Comment:     v.Comment,
+			Declaration: v.Declaration,
 		})
 	}
 
-	// Format in markdown
-	mdContent := model.FormatConstAndVarDocMarkdown(constants, variables)
-
-	return &mcp.CallToolResult{
-		Content: []mcp.CallToolContent{
-			mcp.TextContent{Text: mdContent},
-		},
-	}, nil
+	// Format in the requested output format
+	data := model.FormatConstAndVarDoc(constants, variables)
+	setStructured(ctx, data)
+	if h.isJSON(req.OutputFormat) {
+		return h.packageResult(req.PackageName, data), nil
+	}
+	return h.packageResult(req.PackageName, model.FormatConstAndVarDocMarkdown(constants, variables)), nil
 }
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	godoc "github.com/budougumi0617/godoc-mcp"
+	"github.com/budougumi0617/godoc-mcp/internal/parser"
+)
+
+func TestToolHandler_OutputFormat(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/m\n\ngo 1.24\n",
+		"m.go":   "package m\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+	ws, err := parser.NewWorkspace([]string{dir}, parser.Options{})
+	if err != nil {
+		t.Fatalf("parser.NewWorkspace() error = %v", err)
+	}
+	h := NewToolHandler(ws, OutputFormatMarkdown, nil)
+
+	tests := map[string]struct {
+		outputFormat string
+		wantErr      bool
+	}{
+		"default format": {outputFormat: ""},
+		"json":           {outputFormat: "json"},
+		"markdown":       {outputFormat: "markdown"},
+		"unknown format": {outputFormat: "yaml", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var content structuredContent
+			ctx := context.WithValue(context.Background(), structuredContentKey{}, &content)
+			_, err := h.HandleToolGolangListPackages(ctx, &godoc.ToolGolangListPackagesRequest{OutputFormat: tt.outputFormat})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HandleToolGolangListPackages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// The JSON result is the structured content whatever the output format.
+			if !tt.wantErr && !json.Valid(content.data) {
+				t.Errorf("structured content = %q, want a JSON result", content.data)
+			}
+		})
+	}
+}
+
+func TestToolHandler_GetSourceContextLines(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/m\n\ngo 1.24\n",
+		"m.go":   "package m\n\n// Double doubles x.\nfunc Double(x int) int { return 2 * x }\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+	ws, err := parser.NewWorkspace([]string{dir}, parser.Options{})
+	if err != nil {
+		t.Fatalf("parser.NewWorkspace() error = %v", err)
+	}
+	h := NewToolHandler(ws, OutputFormatMarkdown, nil)
+
+	tests := map[string]struct {
+		contextLines int
+		wantErr      bool
+	}{
+		"no context":       {contextLines: 0},
+		"context":          {contextLines: 10},
+		"negative context": {contextLines: -5, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := h.HandleToolGolangGetSource(context.Background(), &godoc.ToolGolangGetSourceRequest{
+				PackageName:  "example.com/m",
+				SymbolName:   "Double",
+				ContextLines: tt.contextLines,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HandleToolGolangGetSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ktr0731/go-mcp/protocol"
+	jsonrpc2 "golang.org/x/exp/jsonrpc2"
+)
+
+// MCP methods of client roots, which the protocol package does not define
+const (
+	methodRootsList        = "roots/list"
+	methodRootsListChanged = "notifications/roots/list_changed"
+)
+
+// rootsTimeout is the time a client has to answer roots/list.
+const rootsTimeout = 30 * time.Second
+
+// NewRootsBinder wraps binder so that the roots of clients supporting them are requested
+// after initialization and whenever a client reports that they changed.
+// update is called with the sorted directories of the roots of every connected client
+// whenever they change, unless no client has any root. Calls to update are serialized.
+func NewRootsBinder(binder jsonrpc2.Binder, update func(dirs []string)) jsonrpc2.Binder {
+	return &rootsBinder{
+		binder: binder,
+		update: update,
+		roots:  make(map[*jsonrpc2.Connection][]string),
+	}
+}
+
+// rootsBinder is a jsonrpc2.Binder tracking the roots of clients.
+type rootsBinder struct {
+	binder jsonrpc2.Binder
+	update func(dirs []string)
+
+	mu      sync.Mutex
+	roots   map[*jsonrpc2.Connection][]string // Root directories of each client
+	current []string                          // Root directories last passed to update
+}
+
+// rootsSession is the roots state of a client connection.
+type rootsSession struct {
+	supported atomic.Bool // Whether the client supports roots, set by initialize
+	listing   sync.Mutex  // Held while listing roots, so that the latest list wins
+}
+
+// Bind implements jsonrpc2.Binder.
+func (b *rootsBinder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	opts, err := b.binder.Bind(ctx, conn)
+	if err != nil {
+		return opts, err
+	}
+	opts.Handler = b.wrap(conn, opts.Handler)
+
+	// Forget the roots of the client once it disconnects
+	go func() {
+		conn.Wait()
+		b.setRoots(conn, nil)
+	}()
+	return opts, nil
+}
+
+// wrap returns a handler that lists the roots of the client once it is initialized and when
+// they change, and passes every request to next.
+func (b *rootsBinder) wrap(conn *jsonrpc2.Connection, next jsonrpc2.Handler) jsonrpc2.Handler {
+	session := &rootsSession{}
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		switch req.Method {
+		case protocol.MethodInitialize:
+			var params protocol.InitializeRequestParams
+			if err := json.Unmarshal(req.Params, &params); err == nil {
+				session.supported.Store(params.Capabilities.Roots != nil)
+			}
+		case protocol.MethodNotificationsInitialized, methodRootsListChanged:
+			if session.supported.Load() {
+				go b.listRoots(conn, session)
+			}
+			// The MCP handler does not know roots notifications
+			if req.Method == methodRootsListChanged {
+				return nil, nil
+			}
+		}
+		return next.Handle(ctx, req)
+	})
+}
+
+// listRoots requests the roots of the client on conn.
+func (b *rootsBinder) listRoots(conn *jsonrpc2.Connection, session *rootsSession) {
+	session.listing.Lock()
+	defer session.listing.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), rootsTimeout)
+	defer cancel()
+	var result struct {
+		Roots []struct {
+			URI  string `json:"uri"`
+			Name string `json:"name,omitempty"`
+		} `json:"roots"`
+	}
+	if err := conn.Call(ctx, methodRootsList, struct{}{}).Await(ctx, &result); err != nil {
+		log.Printf("Failed to list client roots: %v", err)
+		return
+	}
+
+	dirs := make([]string, 0, len(result.Roots))
+	for _, root := range result.Roots {
+		if dir, ok := rootDir(root.URI); ok {
+			dirs = append(dirs, dir)
+		} else {
+			log.Printf("Ignoring client root that is not a local directory: %s", root.URI)
+		}
+	}
+	b.setRoots(conn, dirs)
+}
+
+// setRoots sets the root directories of the client on conn, and updates the roots of the
+// server if the roots of all clients changed.
+func (b *rootsBinder) setRoots(conn *jsonrpc2.Connection, dirs []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(dirs) == 0 {
+		delete(b.roots, conn)
+	} else {
+		b.roots[conn] = dirs
+	}
+
+	seen := make(map[string]bool)
+	var all []string
+	for _, dirs := range b.roots {
+		for _, dir := range dirs {
+			if !seen[dir] {
+				seen[dir] = true
+				all = append(all, dir)
+			}
+		}
+	}
+	sort.Strings(all)
+	if len(all) == 0 || slices.Equal(all, b.current) {
+		return
+	}
+	b.current = all
+	b.update(all)
+}
+
+// rootDir returns the directory of a file URI.
+func rootDir(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" || u.Path == "" {
+		return "", false
+	}
+	path := u.Path
+	// Windows paths are written as file:///C:/path
+	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.Clean(filepath.FromSlash(path)), true
+}
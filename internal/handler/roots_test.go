@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ktr0731/go-mcp/protocol"
+	jsonrpc2 "golang.org/x/exp/jsonrpc2"
+)
+
+func TestRootsBinder(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	// The server answers every request, and records the roots it is updated with
+	updates := make(chan []string, 1)
+	server := jsonrpc2.ConnectionOptions{
+		Handler: jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+			if !req.IsCall() {
+				return nil, nil
+			}
+			return struct{}{}, nil
+		}),
+	}
+	listener, err := jsonrpc2.NetPipe(ctx)
+	if err != nil {
+		t.Fatalf("jsonrpc2.NetPipe() error = %v", err)
+	}
+	srv, err := jsonrpc2.Serve(ctx, listener, NewRootsBinder(server, func(dirs []string) {
+		updates <- dirs
+	}))
+	if err != nil {
+		t.Fatalf("jsonrpc2.Serve() error = %v", err)
+	}
+	t.Cleanup(func() {
+		listener.Close()
+		srv.Wait()
+	})
+
+	// The client lists its current roots
+	var mu sync.Mutex
+	roots := []map[string]string{
+		{"uri": "file:///work/api", "name": "api"},
+		{"uri": "https://example.com/remote"},
+	}
+	client := jsonrpc2.ConnectionOptions{
+		Handler: jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+			if req.Method != methodRootsList {
+				return nil, jsonrpc2.ErrMethodNotFound
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			return map[string]any{"roots": roots}, nil
+		}),
+	}
+	conn, err := jsonrpc2.Dial(ctx, listener.Dialer(), client)
+	if err != nil {
+		t.Fatalf("jsonrpc2.Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	params := protocol.InitializeRequestParams{
+		ProtocolVersion: "2024-11-05",
+		Capabilities:    protocol.ClientCapabilities{Roots: &protocol.RootsCapability{ListChanged: true}},
+		ClientInfo:      protocol.Implementation{Name: "test", Version: "1.0.0"},
+	}
+	if err := conn.Call(ctx, protocol.MethodInitialize, params).Await(ctx, nil); err != nil {
+		t.Fatalf("initialize error = %v", err)
+	}
+	if err := conn.Notify(ctx, protocol.MethodNotificationsInitialized, nil); err != nil {
+		t.Fatalf("notifications/initialized error = %v", err)
+	}
+	wantUpdate(t, updates, []string{filepath.FromSlash("/work/api")})
+
+	// Changed roots are listed again
+	mu.Lock()
+	roots = []map[string]string{{"uri": "file:///work/web"}, {"uri": "file:///work/api"}}
+	mu.Unlock()
+	if err := conn.Notify(ctx, methodRootsListChanged, nil); err != nil {
+		t.Fatalf("notifications/roots/list_changed error = %v", err)
+	}
+	wantUpdate(t, updates, []string{filepath.FromSlash("/work/api"), filepath.FromSlash("/work/web")})
+}
+
+// wantUpdate waits for the roots binder to update the roots to want.
+func wantUpdate(t *testing.T, updates <-chan []string, want []string) {
+	t.Helper()
+
+	select {
+	case got := <-updates:
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("update() roots = %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("roots were not updated to %q", want)
+	}
+}
+
+func TestRootDir(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		uri    string
+		want   string
+		wantOK bool
+	}{
+		"file URI":        {uri: "file:///home/dev/project", want: filepath.FromSlash("/home/dev/project"), wantOK: true},
+		"escaped path":    {uri: "file:///home/dev/my%20project/", want: filepath.FromSlash("/home/dev/my project"), wantOK: true},
+		"other scheme":    {uri: "https://example.com/project", wantOK: false},
+		"file URI no dir": {uri: "file://", wantOK: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := rootDir(tt.uri)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("rootDir(%q) = %q, %v, want %q, %v", tt.uri, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
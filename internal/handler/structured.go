@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/budougumi0617/godoc-mcp/internal/model"
+	"github.com/invopop/jsonschema"
+	mcp "github.com/ktr0731/go-mcp"
+	"github.com/ktr0731/go-mcp/protocol"
+	jsonrpc2 "golang.org/x/exp/jsonrpc2"
+)
+
+// outputTypes maps tool names to the responses they return in JSON format.
+var outputTypes = map[string]any{
+	"golang_list_packages":         &model.ListPackagesResponse{},
+	"golang_get_diagnostics":       &model.DiagnosticsResponse{},
+	"golang_search_symbols":        &model.SearchSymbolsResponse{},
+	"golang_search_docs":           &model.SearchDocsResponse{},
+	"golang_inspect_package":       &model.InspectPackageResponse{},
+	"golang_get_struct_doc":        &model.StructDocResponse{},
+	"golang_get_interface_doc":     &model.InterfaceDocResponse{},
+	"golang_get_type_doc":          &model.TypeDocResponse{},
+	"golang_find_implementations":  &model.ImplementationsResponse{},
+	"golang_find_references":       &model.ReferencesResponse{},
+	"golang_get_callers":           &model.CallsResponse{},
+	"golang_get_callees":           &model.CallsResponse{},
+	"golang_get_dependency_graph":  &model.DependencyGraphResponse{},
+	"golang_get_func_doc":          &model.FuncDocResponse{},
+	"golang_get_method_doc":        &model.MethodDocResponse{},
+	"golang_get_source":            &model.SourceResponse{},
+	"golang_get_const_and_var_doc": &model.ConstAndVarResponse{},
+}
+
+// toolWithOutputSchema is a tool definition with the schema of its structured content,
+// which protocol.Tool does not support.
+type toolWithOutputSchema struct {
+	protocol.Tool
+	OutputSchema *jsonschema.Schema `json:"outputSchema,omitempty"`
+}
+
+// listToolsResult is the result of tools/list.
+type listToolsResult struct {
+	Tools []toolWithOutputSchema `json:"tools"`
+}
+
+// structuredResult is a tool result with structured content, which mcp.CallToolResult does not support.
+type structuredResult struct {
+	*mcp.CallToolResult
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+}
+
+// NewStructuredBinder wraps binder so that tools declare the schema of their JSON results,
+// and return them as structured content whatever the output format of their text content.
+func NewStructuredBinder(binder jsonrpc2.Binder, tools []protocol.Tool) jsonrpc2.Binder {
+	return &structuredBinder{
+		binder: binder,
+		tools:  withOutputSchemas(tools),
+	}
+}
+
+// structuredBinder is a jsonrpc2.Binder adding output schemas and structured content to tool results.
+type structuredBinder struct {
+	binder jsonrpc2.Binder
+	tools  []toolWithOutputSchema
+}
+
+// Bind implements jsonrpc2.Binder.
+func (b *structuredBinder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	opts, err := b.binder.Bind(ctx, conn)
+	if err != nil {
+		return opts, err
+	}
+	opts.Handler = b.wrap(opts.Handler)
+	return opts, nil
+}
+
+// wrap returns a handler that adds output schemas and structured content to the results of next.
+func (b *structuredBinder) wrap(next jsonrpc2.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		var content structuredContent
+		if req.Method == protocol.MethodToolsCall {
+			ctx = context.WithValue(ctx, structuredContentKey{}, &content)
+		}
+		res, err := next.Handle(ctx, req)
+		if err != nil {
+			return res, err
+		}
+
+		switch req.Method {
+		case protocol.MethodToolsList:
+			return &listToolsResult{Tools: b.tools}, nil
+		case protocol.MethodToolsCall:
+			result, ok := res.(*mcp.CallToolResult)
+			if !ok || content.data == nil {
+				return res, nil
+			}
+			return &structuredResult{
+				CallToolResult:    result,
+				StructuredContent: content.data,
+			}, nil
+		}
+		return res, nil
+	})
+}
+
+// structuredContentKey is the context key of the structured content of a tool call.
+type structuredContentKey struct{}
+
+// structuredContent receives the JSON result of a tool call.
+// Tools declaring an output schema must return structured content even when their text is Markdown.
+type structuredContent struct {
+	data json.RawMessage
+}
+
+// setStructured records text, the JSON result of the tool call of ctx, as its structured content.
+// It does nothing outside of a structured binder or if text is not valid JSON.
+func setStructured(ctx context.Context, text string) {
+	content, ok := ctx.Value(structuredContentKey{}).(*structuredContent)
+	if !ok || !json.Valid([]byte(text)) {
+		return
+	}
+	content.data = json.RawMessage(text)
+}
+
+// withOutputSchemas returns the tool definitions with the schemas of their JSON results.
+func withOutputSchemas(tools []protocol.Tool) []toolWithOutputSchema {
+	reflector := jsonschema.Reflector{ExpandedStruct: true}
+	list := make([]toolWithOutputSchema, 0, len(tools))
+	for _, tool := range tools {
+		t := toolWithOutputSchema{Tool: tool}
+		if v, ok := outputTypes[tool.Name]; ok {
+			t.OutputSchema = reflector.Reflect(v)
+		}
+		list = append(list, t)
+	}
+	return list
+}
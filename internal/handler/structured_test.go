@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	godoc "github.com/budougumi0617/godoc-mcp"
+	mcp "github.com/ktr0731/go-mcp"
+	"github.com/ktr0731/go-mcp/protocol"
+	jsonrpc2 "golang.org/x/exp/jsonrpc2"
+)
+
+func TestStructuredBinder_ToolsList(t *testing.T) {
+	t.Parallel()
+
+	b := NewStructuredBinder(nil, godoc.ToolList).(*structuredBinder)
+	h := b.wrap(jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+		return struct{}{}, nil
+	}))
+
+	req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), protocol.MethodToolsList, nil)
+	if err != nil {
+		t.Fatalf("NewCall() error = %v", err)
+	}
+	res, err := h.Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	b2, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got struct {
+		Tools []struct {
+			Name         string          `json:"name"`
+			InputSchema  json.RawMessage `json:"inputSchema"`
+			OutputSchema struct {
+				Type       string         `json:"type"`
+				Properties map[string]any `json:"properties"`
+			} `json:"outputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(b2, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(got.Tools) != len(godoc.ToolList) {
+		t.Fatalf("len(Tools) = %d, want %d", len(got.Tools), len(godoc.ToolList))
+	}
+	for _, tool := range got.Tools {
+		if len(tool.InputSchema) == 0 {
+			t.Errorf("%s: inputSchema is missing", tool.Name)
+		}
+		if tool.OutputSchema.Type != "object" || len(tool.OutputSchema.Properties) == 0 {
+			t.Errorf("%s: outputSchema = %+v, want an object schema", tool.Name, tool.OutputSchema)
+		}
+	}
+}
+
+func TestStructuredBinder_ToolsCall(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		text           string
+		structured     string
+		warning        string
+		wantStructured string
+	}{
+		"json result": {
+			text:           `{"name":"Sum"}`,
+			structured:     `{"name":"Sum"}`,
+			wantStructured: `{"name":"Sum"}`,
+		},
+		"json result with a load warning": {
+			text:           `{"name":"Sum"}`,
+			structured:     `{"name":"Sum"}`,
+			warning:        "Warning: package `example.com/m` has 1 load errors, so this result may be incomplete",
+			wantStructured: `{"name":"Sum"}`,
+		},
+		"markdown result": {
+			text:           "# Function: Sum",
+			structured:     `{"name":"Sum"}`,
+			wantStructured: `{"name":"Sum"}`,
+		},
+		"no structured content": {
+			text: "# Function: Sum",
+		},
+		"invalid structured content": {
+			text:       "# Function: Sum",
+			structured: "# Function: Sum",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			b := NewStructuredBinder(nil, godoc.ToolList).(*structuredBinder)
+			h := b.wrap(jsonrpc2.HandlerFunc(func(ctx context.Context, req *jsonrpc2.Request) (any, error) {
+				if tt.structured != "" {
+					setStructured(ctx, tt.structured)
+				}
+				result := &mcp.CallToolResult{
+					Content: []mcp.CallToolContent{
+						mcp.TextContent{Text: tt.text},
+					},
+				}
+				if tt.warning != "" {
+					result.Content = append(result.Content, mcp.TextContent{Text: tt.warning})
+				}
+				return result, nil
+			}))
+
+			req, err := jsonrpc2.NewCall(jsonrpc2.Int64ID(1), protocol.MethodToolsCall, map[string]any{
+				"name":      "golang_get_func_doc",
+				"arguments": map[string]any{},
+			})
+			if err != nil {
+				t.Fatalf("NewCall() error = %v", err)
+			}
+			res, err := h.Handle(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+
+			b2, err := json.Marshal(res)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var got struct {
+				Content []struct {
+					Text string `json:"text"`
+				} `json:"content"`
+				StructuredContent json.RawMessage `json:"structuredContent"`
+			}
+			if err := json.Unmarshal(b2, &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			wantContent := 1
+			if tt.warning != "" {
+				wantContent = 2
+			}
+			if len(got.Content) != wantContent || got.Content[0].Text != tt.text {
+				t.Errorf("content = %+v, want text %q", got.Content, tt.text)
+			}
+			if string(got.StructuredContent) != tt.wantStructured {
+				t.Errorf("structuredContent = %s, want %s", got.StructuredContent, tt.wantStructured)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -19,12 +20,14 @@ func FormatPackageList(packages []PackageInfo) string {
 }
 
 // FormatPackageInspection formats package inspection results into a JSON string
-func FormatPackageInspection(pkg PackageInfo, structs []StructSummary, funcs []FuncSummary, methods []MethodSummary, includeComments bool) string {
+func FormatPackageInspection(pkg PackageInfo, constants, variables []ValueSummary, funcs []FuncSummary, namedTypes []TypeSummary, examples []Example, includeComments bool) string {
 	response := InspectPackageResponse{
 		Package:   pkg,
-		Structs:   structs,
+		Constants: constants,
+		Variables: variables,
 		Functions: funcs,
-		Methods:   methods,
+		Types:     namedTypes,
+		Examples:  examples,
 	}
 	jsonBytes, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
@@ -34,12 +37,17 @@ func FormatPackageInspection(pkg PackageInfo, structs []StructSummary, funcs []F
 }
 
 // FormatStructDoc formats struct documentation into a JSON string
-func FormatStructDoc(name, comment string, fields []FieldDoc, methods []MethodDoc) string {
+func FormatStructDoc(name, comment, declaration string, fields []FieldDoc, methods []MethodDoc, layout *StructLayoutDoc, examples []Example, typeParams []TypeParamDoc, instantiations []InstantiationDoc) string {
 	response := StructDocResponse{
-		Name:    name,
-		Comment: comment,
-		Fields:  fields,
-		Methods: methods,
+		Name:           name,
+		Comment:        comment,
+		Declaration:    declaration,
+		Fields:         fields,
+		Methods:        methods,
+		Layout:         layout,
+		Examples:       examples,
+		TypeParams:     typeParams,
+		Instantiations: instantiations,
 	}
 	jsonBytes, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
@@ -48,13 +56,172 @@ func FormatStructDoc(name, comment string, fields []FieldDoc, methods []MethodDo
 	return string(jsonBytes)
 }
 
+// FormatInterfaceDoc formats interface documentation into a JSON string
+func FormatInterfaceDoc(name, comment, declaration string, methods []InterfaceMethodDoc, embeddeds, typeSet []string, isConstraint bool, implementations []ImplementationDoc, examples []Example, typeParams []TypeParamDoc, instantiations []InstantiationDoc) string {
+	response := InterfaceDocResponse{
+		Name:            name,
+		Comment:         comment,
+		Declaration:     declaration,
+		Methods:         methods,
+		Embeddeds:       embeddeds,
+		TypeSet:         typeSet,
+		IsConstraint:    isConstraint,
+		Implementations: implementations,
+		Examples:        examples,
+		TypeParams:      typeParams,
+		Instantiations:  instantiations,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format interface documentation: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatTypeDoc formats named type documentation into a JSON string
+func FormatTypeDoc(name, kind, comment, declaration, underlying string, constants []ConstDoc, constructors []ConstructorDoc, methods []MethodDoc, examples []Example, typeParams []TypeParamDoc) string {
+	response := TypeDocResponse{
+		Name:         name,
+		Kind:         kind,
+		Comment:      comment,
+		Declaration:  declaration,
+		Underlying:   underlying,
+		Constants:    constants,
+		Constructors: constructors,
+		Methods:      methods,
+		Examples:     examples,
+		TypeParams:   typeParams,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format type documentation: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatImplementations formats implementation relationships into a JSON string
+func FormatImplementations(name, importPath string, isInterface bool, implementations, interfaces []ImplementationDoc) string {
+	response := ImplementationsResponse{
+		Name:            name,
+		ImportPath:      importPath,
+		IsInterface:     isInterface,
+		Implementations: implementations,
+		Interfaces:      interfaces,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format implementations: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatCallers formats the callers of a function into a JSON string
+func FormatCallers(function, importPath, algorithm string, depth int, calls []CallDoc) string {
+	return formatCalls(function, importPath, algorithm, depth, calls)
+}
+
+// FormatCallees formats the callees of a function into a JSON string
+func FormatCallees(function, importPath, algorithm string, depth int, calls []CallDoc) string {
+	return formatCalls(function, importPath, algorithm, depth, calls)
+}
+
+// formatCalls formats calls into a JSON string.
+func formatCalls(function, importPath, algorithm string, depth int, calls []CallDoc) string {
+	response := CallsResponse{
+		Function:   function,
+		ImportPath: importPath,
+		Algorithm:  algorithm,
+		Depth:      depth,
+		Calls:      calls,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format calls: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatReferences formats the references to a symbol into a JSON string
+func FormatReferences(name, kind, importPath, position string, references []ReferenceDoc) string {
+	response := ReferencesResponse{
+		Name:       name,
+		Kind:       kind,
+		ImportPath: importPath,
+		Position:   position,
+		References: references,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format references: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatDependencyGraph formats an import graph into a JSON string
+func FormatDependencyGraph(pkg string, transitive bool, packages []GraphPackageDoc, imports []ImportEdgeDoc, cycles [][]string, why *DependencyPathDoc, violations []LayerViolationDoc) string {
+	response := DependencyGraphResponse{
+		Package:    pkg,
+		Transitive: transitive,
+		Packages:   packages,
+		Imports:    imports,
+		Cycles:     cycles,
+		Why:        why,
+		Violations: violations,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format dependency graph: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatDiagnostics formats package load errors into a JSON string
+func FormatDiagnostics(packages []PackageDiagnosticsDoc) string {
+	response := DiagnosticsResponse{
+		Packages: packages,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format diagnostics: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatSearchSymbols formats symbol search results into a JSON string
+func FormatSearchSymbols(query string, symbols []SymbolDoc) string {
+	response := SearchSymbolsResponse{
+		Query:   query,
+		Symbols: symbols,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format symbol search results: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
+// FormatSearchDocs formats full-text search results into a JSON string
+func FormatSearchDocs(query string, matches []DocMatchDoc) string {
+	response := SearchDocsResponse{
+		Query:   query,
+		Matches: matches,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format documentation search results: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
 // FormatFuncDoc formats function documentation into a JSON string
-func FormatFuncDoc(name, signature, comment string, examples []Example) string {
+func FormatFuncDoc(name, signature, comment string, examples []Example, typeParams []TypeParamDoc, instantiations []InstantiationDoc) string {
 	response := FuncDocResponse{
-		Name:      name,
-		Signature: signature,
-		Comment:   comment,
-		Examples:  examples,
+		Name:           name,
+		Signature:      signature,
+		Comment:        comment,
+		Examples:       examples,
+		TypeParams:     typeParams,
+		Instantiations: instantiations,
 	}
 	jsonBytes, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
@@ -64,13 +231,14 @@ func FormatFuncDoc(name, signature, comment string, examples []Example) string {
 }
 
 // FormatMethodDoc formats method documentation into a JSON string
-func FormatMethodDoc(receiverType, name, signature, comment string, examples []Example) string {
+func FormatMethodDoc(receiverType, name, signature, comment string, examples []Example, typeParams []TypeParamDoc) string {
 	response := MethodDocResponse{
 		ReceiverType: receiverType,
 		Name:         name,
 		Signature:    signature,
 		Comment:      comment,
 		Examples:     examples,
+		TypeParams:   typeParams,
 	}
 	jsonBytes, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
@@ -79,6 +247,24 @@ func FormatMethodDoc(receiverType, name, signature, comment string, examples []E
 	return string(jsonBytes)
 }
 
+// FormatSource formats the source code of a declaration into a JSON string
+func FormatSource(name, kind, importPath, file string, startLine, endLine int, source string) string {
+	response := SourceResponse{
+		Name:       name,
+		Kind:       kind,
+		ImportPath: importPath,
+		File:       file,
+		StartLine:  startLine,
+		EndLine:    endLine,
+		Source:     source,
+	}
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Failed to format source: %v"}`, err)
+	}
+	return string(jsonBytes)
+}
+
 // FormatConstAndVarDoc formats constant and variable documentation into a JSON string
 func FormatConstAndVarDoc(constants []ConstDoc, variables []VarDoc) string {
 	response := ConstAndVarResponse{
@@ -97,8 +283,18 @@ func FormatPackageListMarkdown(packages []PackageInfo) string {
 	var sb strings.Builder
 	sb.WriteString("# Packages\n\n")
 	for _, pkg := range packages {
-		sb.WriteString(fmt.Sprintf("## %s\n", pkg.Name))
+		if pkg.IsDependency {
+			sb.WriteString(fmt.Sprintf("## %s (dependency)\n", pkg.Name))
+		} else {
+			sb.WriteString(fmt.Sprintf("## %s\n", pkg.Name))
+		}
 		sb.WriteString(fmt.Sprintf("Import Path: `%s`\n\n", pkg.ImportPath))
+		if pkg.Module != "" {
+			sb.WriteString(fmt.Sprintf("Module: `%s`\n\n", pkg.Module))
+		}
+		if pkg.ErrorCount > 0 {
+			sb.WriteString(fmt.Sprintf("**%d load errors**: documentation may be incomplete\n\n", pkg.ErrorCount))
+		}
 		if pkg.Comment != "" {
 			sb.WriteString(fmt.Sprintf("%s\n\n", pkg.Comment))
 		}
@@ -107,7 +303,8 @@ func FormatPackageListMarkdown(packages []PackageInfo) string {
 }
 
 // formatPackageInspection formats package inspection results into a markdown string
-func FormatPackageInspectionMarkdown(pkg PackageInfo, structs []StructSummary, funcs []FuncSummary, methods []MethodSummary, includeComments bool) string {
+// Constants, variables, constructors and methods associated with a type are listed under it, as go doc does.
+func FormatPackageInspectionMarkdown(pkg PackageInfo, constants, variables []ValueSummary, funcs []FuncSummary, namedTypes []TypeSummary, examples []Example, includeComments bool) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("# Package: %s\n\n", pkg.Name))
 	sb.WriteString(fmt.Sprintf("Import Path: `%s`\n\n", pkg.ImportPath))
@@ -115,14 +312,16 @@ func FormatPackageInspectionMarkdown(pkg PackageInfo, structs []StructSummary, f
 		sb.WriteString(fmt.Sprintf("%s\n\n", pkg.Comment))
 	}
 
-	if len(structs) > 0 {
-		sb.WriteString("## Structs\n\n")
-		for _, s := range structs {
-			sb.WriteString(fmt.Sprintf("### %s\n", s.Name))
-			if includeComments && s.Comment != "" {
-				sb.WriteString(fmt.Sprintf("%s\n\n", s.Comment))
-			}
-		}
+	if len(constants) > 0 {
+		sb.WriteString("## Constants\n\n")
+		writeValueSummaries(&sb, constants, includeComments)
+		sb.WriteString("\n")
+	}
+
+	if len(variables) > 0 {
+		sb.WriteString("## Variables\n\n")
+		writeValueSummaries(&sb, variables, includeComments)
+		sb.WriteString("\n")
 	}
 
 	if len(funcs) > 0 {
@@ -135,106 +334,641 @@ func FormatPackageInspectionMarkdown(pkg PackageInfo, structs []StructSummary, f
 		}
 	}
 
-	if len(methods) > 0 {
-		sb.WriteString("## Methods\n\n")
-		for _, m := range methods {
-			sb.WriteString(fmt.Sprintf("### %s.%s\n", m.ReceiverType, m.Name))
-			if includeComments && m.Comment != "" {
-				sb.WriteString(fmt.Sprintf("%s\n\n", m.Comment))
+	if len(namedTypes) > 0 {
+		sb.WriteString("## Types\n\n")
+		for _, t := range namedTypes {
+			sb.WriteString(fmt.Sprintf("### %s (%s)\n", t.Name, t.Kind))
+			if includeComments && t.Comment != "" {
+				sb.WriteString(fmt.Sprintf("%s\n\n", t.Comment))
 			}
+			if len(t.Constants) > 0 {
+				sb.WriteString("Constants:\n")
+				writeValueSummaries(&sb, t.Constants, includeComments)
+			}
+			if len(t.Variables) > 0 {
+				sb.WriteString("Variables:\n")
+				writeValueSummaries(&sb, t.Variables, includeComments)
+			}
+			if len(t.Constructors) > 0 {
+				sb.WriteString("Constructors:\n")
+				writeFuncSummaries(&sb, t.Constructors, includeComments)
+			}
+			if len(t.Methods) > 0 {
+				sb.WriteString("Methods:\n")
+				writeFuncSummaries(&sb, t.Methods, includeComments)
+			}
+			sb.WriteString("\n")
 		}
 	}
 
+	writeExamples(&sb, examples)
+
 	return sb.String()
 }
 
+// writeMethodDocs writes methods with the embedded type they are promoted from.
+func writeMethodDocs(sb *strings.Builder, methods []MethodDoc) {
+	for _, m := range methods {
+		sb.WriteString(fmt.Sprintf("### %s\n", m.Name))
+		sb.WriteString(fmt.Sprintf("Signature: `%s`\n", m.Signature))
+		if m.PromotedFrom != "" {
+			sb.WriteString(fmt.Sprintf("Promoted from: `%s`\n", m.PromotedFrom))
+		}
+		if m.PointerReceiver {
+			sb.WriteString("Pointer receiver: only in the method set of the pointer type\n")
+		}
+		if m.Comment != "" {
+			sb.WriteString(fmt.Sprintf("%s\n\n", m.Comment))
+		}
+	}
+}
+
+// writeValueSummaries writes constant or variable groups as a markdown list.
+func writeValueSummaries(sb *strings.Builder, values []ValueSummary, includeComments bool) {
+	for _, v := range values {
+		sb.WriteString(fmt.Sprintf("- `%s`", strings.Join(v.Names, "`, `")))
+		if includeComments && v.Comment != "" {
+			sb.WriteString(fmt.Sprintf(": %s", oneLine(v.Comment)))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// writeFuncSummaries writes functions or methods as a markdown list.
+func writeFuncSummaries(sb *strings.Builder, funcs []FuncSummary, includeComments bool) {
+	for _, f := range funcs {
+		sb.WriteString(fmt.Sprintf("- `%s`", f.Name))
+		if includeComments && f.Comment != "" {
+			sb.WriteString(fmt.Sprintf(": %s", oneLine(f.Comment)))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// oneLine joins the lines of a comment so that it fits in a list item.
+func oneLine(comment string) string {
+	return strings.Join(strings.Fields(comment), " ")
+}
+
 // formatStructDoc formats struct documentation into a markdown string
-func FormatStructDocMarkdown(name, comment string, fields []FieldDoc, methods []MethodDoc) string {
+func FormatStructDocMarkdown(name, comment, declaration string, fields []FieldDoc, methods []MethodDoc, layout *StructLayoutDoc, examples []Example, typeParams []TypeParamDoc, instantiations []InstantiationDoc) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("# Struct: %s\n\n", name))
+	writeDeclaration(&sb, declaration)
 	if comment != "" {
 		sb.WriteString(fmt.Sprintf("%s\n\n", comment))
 	}
+	writeTypeParams(&sb, typeParams)
+	writeInstantiations(&sb, name, instantiations)
+
+	if layout != nil {
+		sb.WriteString("## Layout\n\n")
+		sb.WriteString(fmt.Sprintf("Size: %d bytes, Align: %d bytes (GOARCH=%s)\n\n", layout.Size, layout.Align, layout.Arch))
+	}
 
 	if len(fields) > 0 {
 		sb.WriteString("## Fields\n\n")
 		for _, f := range fields {
 			sb.WriteString(fmt.Sprintf("### %s\n", f.Name))
 			sb.WriteString(fmt.Sprintf("Type: `%s`\n", f.Type))
+			if f.Embedded {
+				sb.WriteString("Embedded\n")
+			}
+			if f.Tag != "" {
+				sb.WriteString(fmt.Sprintf("Tag: `%s`\n", f.Tag))
+			}
+			if f.Layout != nil {
+				sb.WriteString(fmt.Sprintf("Offset: %d, Size: %d, Align: %d\n", f.Layout.Offset, f.Layout.Size, f.Layout.Align))
+			}
 			if f.Comment != "" {
 				sb.WriteString(fmt.Sprintf("%s\n\n", f.Comment))
 			}
 		}
 	}
 
+	if len(methods) > 0 {
+		sb.WriteString("## Methods\n\n")
+		writeMethodDocs(&sb, methods)
+	}
+
+	return sb.String()
+}
+
+// FormatInterfaceDocMarkdown formats interface documentation into a markdown string
+func FormatInterfaceDocMarkdown(name, comment, declaration string, methods []InterfaceMethodDoc, embeddeds, typeSet []string, isConstraint bool, implementations []ImplementationDoc, examples []Example, typeParams []TypeParamDoc, instantiations []InstantiationDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Interface: %s\n\n", name))
+	writeDeclaration(&sb, declaration)
+	if comment != "" {
+		sb.WriteString(fmt.Sprintf("%s\n\n", comment))
+	}
+	if isConstraint {
+		sb.WriteString("This interface can only be used as a type constraint.\n\n")
+	}
+	writeTypeParams(&sb, typeParams)
+	writeInstantiations(&sb, name, instantiations)
+
+	if len(embeddeds) > 0 {
+		sb.WriteString("## Embedded Interfaces\n\n")
+		for _, e := range embeddeds {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", e))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(typeSet) > 0 {
+		sb.WriteString("## Type Set\n\n")
+		sb.WriteString(fmt.Sprintf("`%s`\n\n", strings.Join(typeSet, " | ")))
+	}
+
 	if len(methods) > 0 {
 		sb.WriteString("## Methods\n\n")
 		for _, m := range methods {
 			sb.WriteString(fmt.Sprintf("### %s\n", m.Name))
 			sb.WriteString(fmt.Sprintf("Signature: `%s`\n", m.Signature))
+			if m.EmbeddedFrom != "" {
+				sb.WriteString(fmt.Sprintf("Embedded from: `%s`\n", m.EmbeddedFrom))
+			}
 			if m.Comment != "" {
 				sb.WriteString(fmt.Sprintf("%s\n\n", m.Comment))
 			}
 		}
 	}
 
+	if len(implementations) > 0 {
+		sb.WriteString("## Implementations\n\n")
+		writeImplementationList(&sb, implementations)
+	}
+
 	return sb.String()
 }
 
-// formatFuncDoc formats function documentation into a markdown string
-func FormatFuncDocMarkdown(name, signature, comment string, examples []Example) string {
+// FormatTypeDocMarkdown formats named type documentation into a markdown string
+func FormatTypeDocMarkdown(name, kind, comment, declaration, underlying string, constants []ConstDoc, constructors []ConstructorDoc, methods []MethodDoc, examples []Example, typeParams []TypeParamDoc) string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("# Function: %s\n\n", name))
-	sb.WriteString(fmt.Sprintf("Signature: `%s`\n\n", signature))
+	sb.WriteString(fmt.Sprintf("# Type: %s\n\n", name))
+	writeDeclaration(&sb, declaration)
+	if kind == "alias" {
+		sb.WriteString(fmt.Sprintf("Alias of: `%s`\n\n", underlying))
+	} else {
+		sb.WriteString(fmt.Sprintf("Underlying type: `%s`\n\n", underlying))
+	}
 	if comment != "" {
 		sb.WriteString(fmt.Sprintf("%s\n\n", comment))
 	}
+	writeTypeParams(&sb, typeParams)
 
-	if len(examples) > 0 {
-		sb.WriteString("## Examples\n\n")
-		for _, e := range examples {
-			sb.WriteString(fmt.Sprintf("### %s\n", e.Name))
-			sb.WriteString("```go\n")
-			sb.WriteString(e.Code)
-			sb.WriteString("\n```\n")
-			if e.Output != "" {
-				sb.WriteString("Output:\n```\n")
-				sb.WriteString(e.Output)
-				sb.WriteString("\n```\n")
+	if len(constants) > 0 {
+		sb.WriteString("## Constants\n\n")
+		var prev string
+		for _, c := range constants {
+			sb.WriteString(fmt.Sprintf("### %s\n", c.Name))
+			if c.Declaration != prev {
+				writeDeclaration(&sb, c.Declaration)
+				prev = c.Declaration
+			}
+			sb.WriteString(fmt.Sprintf("Value: `%s`\n", c.Value))
+			if c.Comment != "" {
+				sb.WriteString(fmt.Sprintf("%s\n\n", c.Comment))
 			}
-			sb.WriteString("\n")
+		}
+	}
+
+	if len(constructors) > 0 {
+		sb.WriteString("## Constructors\n\n")
+		for _, f := range constructors {
+			sb.WriteString(fmt.Sprintf("### %s\n", f.Name))
+			writeDeclaration(&sb, f.Signature)
+			if f.Comment != "" {
+				sb.WriteString(fmt.Sprintf("%s\n\n", f.Comment))
+			}
+		}
+	}
+
+	if len(methods) > 0 {
+		sb.WriteString("## Methods\n\n")
+		writeMethodDocs(&sb, methods)
+	}
+
+	writeExamples(&sb, examples)
+
+	return sb.String()
+}
+
+// FormatImplementationsMarkdown formats implementation relationships into a markdown string
+func FormatImplementationsMarkdown(name, importPath string, isInterface bool, implementations, interfaces []ImplementationDoc) string {
+	var sb strings.Builder
+	if isInterface {
+		sb.WriteString(fmt.Sprintf("# Implementations of %s\n\n", name))
+	} else {
+		sb.WriteString(fmt.Sprintf("# Interfaces implemented by %s\n\n", name))
+	}
+	sb.WriteString(fmt.Sprintf("Import Path: `%s`\n\n", importPath))
+
+	switch {
+	case isInterface && len(implementations) > 0:
+		writeImplementationList(&sb, implementations)
+	case !isInterface && len(interfaces) > 0:
+		writeImplementationList(&sb, interfaces)
+	default:
+		sb.WriteString("None found in the loaded packages.\n")
+	}
+
+	return sb.String()
+}
+
+// writeImplementationList writes implementation relationships as a markdown list.
+// Types that only take part through their pointer type are prefixed with "*".
+func writeImplementationList(sb *strings.Builder, implementations []ImplementationDoc) {
+	for _, impl := range implementations {
+		typeName := impl.Name
+		if impl.IsPointer {
+			typeName = "*" + typeName
+		}
+		sb.WriteString(fmt.Sprintf("- `%s` in `%s` (%s)\n", typeName, impl.ImportPath, impl.Position))
+	}
+	sb.WriteString("\n")
+}
+
+// FormatCallersMarkdown formats the callers of a function into a markdown string
+func FormatCallersMarkdown(function, importPath, algorithm string, depth int, calls []CallDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Callers of %s\n\n", function))
+	writeCalls(&sb, importPath, algorithm, depth, calls, true)
+	return sb.String()
+}
+
+// FormatCalleesMarkdown formats the callees of a function into a markdown string
+func FormatCalleesMarkdown(function, importPath, algorithm string, depth int, calls []CallDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Callees of %s\n\n", function))
+	writeCalls(&sb, importPath, algorithm, depth, calls, false)
+	return sb.String()
+}
+
+// writeCalls writes calls as markdown lists grouped by depth.
+// Each call is listed by its caller if callers is true, and by its callee otherwise.
+func writeCalls(sb *strings.Builder, importPath, algorithm string, depth int, calls []CallDoc, callers bool) {
+	sb.WriteString(fmt.Sprintf("Import Path: `%s`\n\n", importPath))
+	sb.WriteString(fmt.Sprintf("Algorithm: %s, depth: %d\n\n", algorithm, depth))
+	if len(calls) == 0 {
+		sb.WriteString("No calls found.\n")
+		return
+	}
+
+	current := 0
+	for _, c := range calls {
+		if c.Depth != current {
+			if current != 0 {
+				sb.WriteString("\n")
+			}
+			current = c.Depth
+			sb.WriteString(fmt.Sprintf("## Depth %d\n\n", current))
+		}
+		if callers {
+			sb.WriteString(fmt.Sprintf("- `%s` calls `%s`", c.Caller, c.Callee))
+		} else {
+			sb.WriteString(fmt.Sprintf("- `%s` called by `%s`", c.Callee, c.Caller))
+		}
+		if c.Position != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", c.Position))
+		}
+		if c.Dynamic {
+			sb.WriteString(" [dynamic]")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// FormatReferencesMarkdown formats the references to a symbol into a markdown string.
+// References are grouped by file.
+func FormatReferencesMarkdown(name, kind, importPath, position string, references []ReferenceDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# References to %s\n\n", name))
+	sb.WriteString(fmt.Sprintf("Import Path: `%s`\n\n", importPath))
+	sb.WriteString(fmt.Sprintf("Declared as %s at %s\n\n", kind, position))
+	if len(references) == 0 {
+		sb.WriteString("No references found.\n")
+		return sb.String()
+	}
+
+	file := ""
+	for _, r := range references {
+		if r.File != file {
+			if file != "" {
+				sb.WriteString("\n")
+			}
+			file = r.File
+			sb.WriteString(fmt.Sprintf("## %s\n\n", file))
+		}
+		sb.WriteString(fmt.Sprintf("- %d:%d", r.Line, r.Column))
+		if r.Enclosing != "" {
+			sb.WriteString(fmt.Sprintf(" in `%s`", r.Enclosing))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// Diagram formats of dependency graphs
+const (
+	DiagramMermaid = "mermaid" // Mermaid flowchart
+	DiagramDOT     = "dot"     // Graphviz DOT
+)
+
+// FormatDependencyGraphMarkdown formats an import graph into a markdown string,
+// drawing the graph as a diagram in the specified format.
+func FormatDependencyGraphMarkdown(pkg string, transitive bool, packages []GraphPackageDoc, imports []ImportEdgeDoc, cycles [][]string, why *DependencyPathDoc, violations []LayerViolationDoc, diagram string) string {
+	var sb strings.Builder
+	if pkg != "" {
+		sb.WriteString(fmt.Sprintf("# Dependency graph of %s\n\n", pkg))
+	} else {
+		sb.WriteString("# Dependency graph\n\n")
+	}
+	if transitive {
+		sb.WriteString(fmt.Sprintf("%d packages, transitive imports\n\n", len(packages)))
+	} else {
+		sb.WriteString(fmt.Sprintf("%d packages, direct imports\n\n", len(packages)))
+	}
+
+	if diagram == DiagramDOT {
+		sb.WriteString("```dot\n")
+		writeDOT(&sb, packages, imports)
+	} else {
+		sb.WriteString("```mermaid\n")
+		writeMermaid(&sb, packages, imports)
+	}
+	sb.WriteString("```\n\n")
+
+	if len(cycles) > 0 {
+		sb.WriteString("## Import Cycles\n\n")
+		for _, cycle := range cycles {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", strings.Join(cycle, "`, `")))
+		}
+		sb.WriteString("\n")
+	}
+
+	if why != nil {
+		sb.WriteString(fmt.Sprintf("## Why %s depends on %s\n\n", why.From, why.To))
+		if len(why.Path) == 0 {
+			sb.WriteString(fmt.Sprintf("`%s` does not depend on `%s`.\n\n", why.From, why.To))
+		} else {
+			sb.WriteString(fmt.Sprintf("`%s`\n\n", strings.Join(why.Path, "` → `")))
+		}
+	}
+
+	if len(violations) > 0 {
+		sb.WriteString("## Layering Violations\n\n")
+		for _, v := range violations {
+			sb.WriteString(fmt.Sprintf("- `%s` must not depend on `%s`", v.From, v.To))
+			if v.Reason != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", v.Reason))
+			}
+			sb.WriteString(fmt.Sprintf(": `%s`\n", strings.Join(v.Path, "` → `")))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// writeMermaid writes an import graph as a Mermaid flowchart.
+// Packages outside the root directory are drawn with dashed borders.
+func writeMermaid(sb *strings.Builder, packages []GraphPackageDoc, imports []ImportEdgeDoc) {
+	sb.WriteString("graph LR\n")
+	ids := make(map[string]string, len(packages))
+	var deps []string
+	for i, pkg := range packages {
+		id := fmt.Sprintf("n%d", i)
+		ids[pkg.ImportPath] = id
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", id, pkg.ImportPath))
+		if pkg.IsDependency {
+			deps = append(deps, id)
+		}
+	}
+	for _, edge := range imports {
+		sb.WriteString(fmt.Sprintf("  %s --> %s\n", ids[edge.From], ids[edge.To]))
+	}
+	if len(deps) > 0 {
+		sb.WriteString("  classDef dependency stroke-dasharray: 5 5\n")
+		sb.WriteString(fmt.Sprintf("  class %s dependency\n", strings.Join(deps, ",")))
+	}
+}
+
+// writeDOT writes an import graph in the Graphviz DOT language.
+// Packages outside the root directory are drawn with dashed borders.
+func writeDOT(sb *strings.Builder, packages []GraphPackageDoc, imports []ImportEdgeDoc) {
+	sb.WriteString("digraph imports {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	for _, pkg := range packages {
+		if pkg.IsDependency {
+			sb.WriteString(fmt.Sprintf("  %s [style=dashed];\n", strconv.Quote(pkg.ImportPath)))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s;\n", strconv.Quote(pkg.ImportPath)))
+		}
+	}
+	for _, edge := range imports {
+		sb.WriteString(fmt.Sprintf("  %s -> %s;\n", strconv.Quote(edge.From), strconv.Quote(edge.To)))
+	}
+	sb.WriteString("}\n")
+}
+
+// FormatDiagnosticsMarkdown formats package load errors into a markdown string
+func FormatDiagnosticsMarkdown(packages []PackageDiagnosticsDoc) string {
+	var sb strings.Builder
+	sb.WriteString("# Diagnostics\n\n")
+	if len(packages) == 0 {
+		sb.WriteString("All packages loaded without errors.\n")
+		return sb.String()
+	}
+
+	for _, pkg := range packages {
+		if pkg.IsDependency {
+			sb.WriteString(fmt.Sprintf("## %s (dependency)\n\n", pkg.ImportPath))
+		} else {
+			sb.WriteString(fmt.Sprintf("## %s\n\n", pkg.ImportPath))
+		}
+		writePackageErrors(&sb, pkg.Errors)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// maxWarnedErrors is the number of errors listed in a load warning.
+const maxWarnedErrors = 5
+
+// FormatLoadWarning formats a warning that a result comes from a package with load errors,
+// listing the first errors.
+func FormatLoadWarning(importPath string, errs []PackageErrorDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Warning: package `%s` has %d load errors, so this result may be incomplete:\n\n", importPath, len(errs)))
+	writePackageErrors(&sb, errs[:min(len(errs), maxWarnedErrors)])
+	if len(errs) > maxWarnedErrors {
+		sb.WriteString(fmt.Sprintf("- and %d more\n", len(errs)-maxWarnedErrors))
+	}
+	sb.WriteString("\nUse golang_get_diagnostics to list all errors.\n")
+	return sb.String()
+}
+
+// writePackageErrors writes package errors as a markdown list.
+func writePackageErrors(sb *strings.Builder, errs []PackageErrorDoc) {
+	for _, e := range errs {
+		if e.Position != "" {
+			sb.WriteString(fmt.Sprintf("- %s error at %s: %s\n", e.Kind, e.Position, e.Message))
+		} else {
+			sb.WriteString(fmt.Sprintf("- %s error: %s\n", e.Kind, e.Message))
+		}
+	}
+}
+
+// FormatSearchSymbolsMarkdown formats symbol search results into a markdown string
+func FormatSearchSymbolsMarkdown(query string, symbols []SymbolDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Symbols matching: %s\n\n", query))
+	if len(symbols) == 0 {
+		sb.WriteString("No symbols found.\n")
+		return sb.String()
+	}
+
+	for _, s := range symbols {
+		sb.WriteString(fmt.Sprintf("- `%s` (%s) in `%s` (%s)", s.Name, s.Kind, s.ImportPath, s.Position))
+		if s.Summary != "" {
+			sb.WriteString(fmt.Sprintf(": %s", s.Summary))
+		}
+		sb.WriteString("\n")
+		if s.Signature != "" {
+			sb.WriteString(fmt.Sprintf("  `%s`\n", s.Signature))
 		}
 	}
 
 	return sb.String()
 }
 
+// FormatSearchDocsMarkdown formats full-text search results into a markdown string
+func FormatSearchDocsMarkdown(query string, matches []DocMatchDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Documentation matching: %s\n\n", query))
+	if len(matches) == 0 {
+		sb.WriteString("No documentation found.\n")
+		return sb.String()
+	}
+
+	for _, m := range matches {
+		sb.WriteString(fmt.Sprintf("## %s (%s)\n", m.Name, m.Kind))
+		sb.WriteString(fmt.Sprintf("Import Path: `%s`\n", m.ImportPath))
+		if m.Position != "" {
+			sb.WriteString(fmt.Sprintf("Position: %s\n", m.Position))
+		}
+		sb.WriteString(fmt.Sprintf("\n> %s\n\n", m.Snippet))
+	}
+
+	return sb.String()
+}
+
+// formatFuncDoc formats function documentation into a markdown string
+func FormatFuncDocMarkdown(name, signature, comment string, examples []Example, typeParams []TypeParamDoc, instantiations []InstantiationDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Function: %s\n\n", name))
+	writeDeclaration(&sb, signature)
+	if comment != "" {
+		sb.WriteString(fmt.Sprintf("%s\n\n", comment))
+	}
+	writeTypeParams(&sb, typeParams)
+	writeInstantiations(&sb, name, instantiations)
+
+	writeExamples(&sb, examples)
+
+	return sb.String()
+}
+
 // formatMethodDoc formats method documentation into a markdown string
-func FormatMethodDocMarkdown(receiverType, name, signature, comment string, examples []Example) string {
+func FormatMethodDocMarkdown(receiverType, name, signature, comment string, examples []Example, typeParams []TypeParamDoc) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("# Method: %s.%s\n\n", receiverType, name))
-	sb.WriteString(fmt.Sprintf("Signature: `%s`\n\n", signature))
+	writeDeclaration(&sb, signature)
 	if comment != "" {
 		sb.WriteString(fmt.Sprintf("%s\n\n", comment))
 	}
+	writeTypeParams(&sb, typeParams)
 
-	if len(examples) > 0 {
-		sb.WriteString("## Examples\n\n")
-		for _, e := range examples {
-			sb.WriteString(fmt.Sprintf("### %s\n", e.Name))
-			sb.WriteString("```go\n")
-			sb.WriteString(e.Code)
-			sb.WriteString("\n```\n")
-			if e.Output != "" {
+	writeExamples(&sb, examples)
+
+	return sb.String()
+}
+
+// FormatSourceMarkdown formats the source code of a declaration into a markdown string
+func FormatSourceMarkdown(name, kind, importPath, file string, startLine, endLine int, source string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Source: %s\n\n", name))
+	sb.WriteString(fmt.Sprintf("%s in `%s`, %s:%d-%d\n\n", kind, importPath, file, startLine, endLine))
+	sb.WriteString("```go\n")
+	sb.WriteString(source)
+	sb.WriteString("\n```\n")
+	return sb.String()
+}
+
+// writeDeclaration writes a Go declaration as a code block.
+func writeDeclaration(sb *strings.Builder, declaration string) {
+	if declaration == "" {
+		return
+	}
+	sb.WriteString("```go\n")
+	sb.WriteString(declaration)
+	sb.WriteString("\n```\n\n")
+}
+
+// writeExamples writes examples as a markdown section.
+func writeExamples(sb *strings.Builder, examples []Example) {
+	if len(examples) == 0 {
+		return
+	}
+	sb.WriteString("## Examples\n\n")
+	for _, e := range examples {
+		sb.WriteString(fmt.Sprintf("### %s\n", e.Name))
+		sb.WriteString("```go\n")
+		sb.WriteString(e.Code)
+		sb.WriteString("\n```\n")
+		if e.Output != "" {
+			if e.Unordered {
+				sb.WriteString("Unordered output:\n```\n")
+			} else {
 				sb.WriteString("Output:\n```\n")
-				sb.WriteString(e.Output)
-				sb.WriteString("\n```\n")
 			}
-			sb.WriteString("\n")
+			sb.WriteString(e.Output)
+			sb.WriteString("\n```\n")
 		}
+		sb.WriteString("\n")
 	}
+}
 
-	return sb.String()
+// writeTypeParams writes type parameters and their type sets as a markdown section.
+func writeTypeParams(sb *strings.Builder, typeParams []TypeParamDoc) {
+	if len(typeParams) == 0 {
+		return
+	}
+	sb.WriteString("## Type Parameters\n\n")
+	for _, tp := range typeParams {
+		sb.WriteString(fmt.Sprintf("- `%s %s`", tp.Name, tp.Constraint))
+		if len(tp.TypeSet) > 0 {
+			sb.WriteString(fmt.Sprintf(": type set `%s`", strings.Join(tp.TypeSet, " | ")))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+// writeInstantiations writes the instantiations of a generic function or type as a markdown section.
+func writeInstantiations(sb *strings.Builder, name string, instantiations []InstantiationDoc) {
+	if len(instantiations) == 0 {
+		return
+	}
+	sb.WriteString("## Instantiations\n\n")
+	for _, inst := range instantiations {
+		sb.WriteString(fmt.Sprintf("- `%s[%s]`: %d use(s), first at %s\n", name, strings.Join(inst.TypeArgs, ", "), inst.Count, inst.Position))
+	}
+	sb.WriteString("\n")
 }
 
 // formatConstAndVarDoc formats constant and variable documentation into a markdown string
@@ -243,8 +977,14 @@ func FormatConstAndVarDocMarkdown(constants []ConstDoc, variables []VarDoc) stri
 
 	if len(constants) > 0 {
 		sb.WriteString("# Constants\n\n")
+		var prev string
 		for _, c := range constants {
 			sb.WriteString(fmt.Sprintf("## %s\n", c.Name))
+			// Constants of the same group share the declaration, so it is written once
+			if c.Declaration != prev {
+				writeDeclaration(&sb, c.Declaration)
+				prev = c.Declaration
+			}
 			sb.WriteString(fmt.Sprintf("Type: `%s`\n", c.Type))
 			sb.WriteString(fmt.Sprintf("Value: `%s`\n", c.Value))
 			if c.Comment != "" {
@@ -255,8 +995,13 @@ func FormatConstAndVarDocMarkdown(constants []ConstDoc, variables []VarDoc) stri
 
 	if len(variables) > 0 {
 		sb.WriteString("# Variables\n\n")
+		var prev string
 		for _, v := range variables {
 			sb.WriteString(fmt.Sprintf("## %s\n", v.Name))
+			if v.Declaration != prev {
+				writeDeclaration(&sb, v.Declaration)
+				prev = v.Declaration
+			}
 			sb.WriteString(fmt.Sprintf("Type: `%s`\n", v.Type))
 			if v.Comment != "" {
 				sb.WriteString(fmt.Sprintf("%s\n\n", v.Comment))
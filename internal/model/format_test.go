@@ -2,6 +2,7 @@ package model
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -25,7 +26,27 @@ func TestFormatPackageList(t *testing.T) {
 					Comment:    "Package 2",
 				},
 			},
-			want: `{"packages":[{"name":"pkg1","import_path":"github.com/example/pkg1","comment":"Package 1"},{"name":"pkg2","import_path":"github.com/example/pkg2","comment":"Package 2"}]}`,
+			want: `{"packages":[{"name":"pkg1","import_path":"github.com/example/pkg1","comment":"Package 1","is_dependency":false},{"name":"pkg2","import_path":"github.com/example/pkg2","comment":"Package 2","is_dependency":false}]}`,
+		},
+		"package with load errors": {
+			packages: []PackageInfo{
+				{
+					Name:       "broken",
+					ImportPath: "github.com/example/broken",
+					ErrorCount: 2,
+				},
+			},
+			want: `{"packages":[{"name":"broken","import_path":"github.com/example/broken","comment":"","is_dependency":false,"error_count":2}]}`,
+		},
+		"packages of a module": {
+			packages: []PackageInfo{
+				{
+					Name:       "api",
+					ImportPath: "github.com/example/mono/api",
+					Module:     "github.com/example/mono/api",
+				},
+			},
+			want: `{"packages":[{"name":"api","import_path":"github.com/example/mono/api","module":"github.com/example/mono/api","comment":"","is_dependency":false}]}`,
 		},
 		"empty packages": {
 			packages: []PackageInfo{},
@@ -61,9 +82,11 @@ func TestFormatPackageInspection(t *testing.T) {
 
 	tests := map[string]struct {
 		pkg             PackageInfo
-		structs         []StructSummary
+		constants       []ValueSummary
+		variables       []ValueSummary
 		funcs           []FuncSummary
-		methods         []MethodSummary
+		namedTypes      []TypeSummary
+		examples        []Example
 		includeComments bool
 		want            string
 	}{
@@ -73,10 +96,16 @@ func TestFormatPackageInspection(t *testing.T) {
 				ImportPath: "github.com/example/testpkg",
 				Comment:    "Test package",
 			},
-			structs: []StructSummary{
+			constants: []ValueSummary{
 				{
-					Name:    "TestStruct",
-					Comment: "Test struct",
+					Names:   []string{"MaxSize"},
+					Comment: "Test constant",
+				},
+			},
+			variables: []ValueSummary{
+				{
+					Names:   []string{"ErrA", "ErrB"},
+					Comment: "Test errors",
 				},
 			},
 			funcs: []FuncSummary{
@@ -85,15 +114,45 @@ func TestFormatPackageInspection(t *testing.T) {
 					Comment: "Test function",
 				},
 			},
-			methods: []MethodSummary{
+			namedTypes: []TypeSummary{
 				{
-					ReceiverType: "TestStruct",
-					Name:         "TestMethod",
-					Comment:      "Test method",
+					Name:    "TestStruct",
+					Kind:    "struct",
+					Comment: "Test struct",
+					Constructors: []FuncSummary{
+						{
+							Name:    "NewTestStruct",
+							Comment: "Test constructor",
+						},
+					},
+					Methods: []FuncSummary{
+						{
+							Name:    "TestMethod",
+							Comment: "Test method",
+						},
+					},
+				},
+				{
+					Name:    "TestMode",
+					Kind:    "basic",
+					Comment: "Test mode",
+					Constants: []ValueSummary{
+						{
+							Names:   []string{"ModeA", "ModeB"},
+							Comment: "Test modes",
+						},
+					},
+				},
+			},
+			examples: []Example{
+				{
+					Name:   "Example_usage",
+					Code:   "fmt.Println(testpkg.TestFunc())",
+					Output: "ok",
 				},
 			},
 			includeComments: true,
-			want:            `{"package":{"name":"testpkg","import_path":"github.com/example/testpkg","comment":"Test package"},"structs":[{"name":"TestStruct","comment":"Test struct"}],"functions":[{"name":"TestFunc","comment":"Test function"}],"methods":[{"receiver_type":"TestStruct","name":"TestMethod","comment":"Test method"}]}`,
+			want:            `{"package":{"name":"testpkg","import_path":"github.com/example/testpkg","comment":"Test package","is_dependency":false},"constants":[{"names":["MaxSize"],"comment":"Test constant"}],"variables":[{"names":["ErrA","ErrB"],"comment":"Test errors"}],"functions":[{"name":"TestFunc","comment":"Test function"}],"types":[{"name":"TestStruct","kind":"struct","comment":"Test struct","constructors":[{"name":"NewTestStruct","comment":"Test constructor"}],"methods":[{"name":"TestMethod","comment":"Test method"}]},{"name":"TestMode","kind":"basic","comment":"Test mode","constants":[{"names":["ModeA","ModeB"],"comment":"Test modes"}]}],"examples":[{"name":"Example_usage","code":"fmt.Println(testpkg.TestFunc())","output":"ok"}]}`,
 		},
 		"empty package": {
 			pkg: PackageInfo{
@@ -101,18 +160,19 @@ func TestFormatPackageInspection(t *testing.T) {
 				ImportPath: "github.com/example/emptypkg",
 				Comment:    "",
 			},
-			structs:         []StructSummary{},
+			constants:       []ValueSummary{},
+			variables:       []ValueSummary{},
 			funcs:           []FuncSummary{},
-			methods:         []MethodSummary{},
+			namedTypes:      []TypeSummary{},
 			includeComments: false,
-			want:            `{"package":{"name":"emptypkg","import_path":"github.com/example/emptypkg","comment":""},"structs":[],"functions":[],"methods":[]}`,
+			want:            `{"package":{"name":"emptypkg","import_path":"github.com/example/emptypkg","comment":"","is_dependency":false},"constants":[],"variables":[],"functions":[],"types":[]}`,
 		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			got := FormatPackageInspection(tt.pkg, tt.structs, tt.funcs, tt.methods, tt.includeComments)
+			got := FormatPackageInspection(tt.pkg, tt.constants, tt.variables, tt.funcs, tt.namedTypes, tt.examples, tt.includeComments)
 			var gotJSON, wantJSON interface{}
 			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
 				t.Errorf("FormatPackageInspection() invalid JSON = %v", err)
@@ -135,15 +195,21 @@ func TestFormatStructDoc(t *testing.T) {
 	t.Parallel()
 
 	tests := map[string]struct {
-		sName   string
-		comment string
-		fields  []FieldDoc
-		methods []MethodDoc
-		want    string
+		sName          string
+		comment        string
+		declaration    string
+		fields         []FieldDoc
+		methods        []MethodDoc
+		layout         *StructLayoutDoc
+		examples       []Example
+		typeParams     []TypeParamDoc
+		instantiations []InstantiationDoc
+		want           string
 	}{
 		"struct with fields and methods": {
-			sName:   "TestStruct",
-			comment: "Test struct documentation",
+			sName:       "TestStruct",
+			comment:     "Test struct documentation",
+			declaration: "type TestStruct struct {\n\tField1 string // Field1 documentation\n}",
 			fields: []FieldDoc{
 				{
 					Name:       "Field1",
@@ -159,21 +225,90 @@ func TestFormatStructDoc(t *testing.T) {
 					Comment:   "Method1 documentation",
 				},
 			},
-			want: `{"name":"TestStruct","comment":"Test struct documentation","fields":[{"name":"Field1","type":"string","comment":"Field1 documentation","is_exported":true}],"methods":[{"name":"Method1","signature":"func (t *TestStruct) Method1() error","comment":"Method1 documentation"}]}`,
+			examples: []Example{
+				{
+					Name:      "ExampleTestStruct",
+					Code:      "for _, s := range []string{\"a\", \"b\"} {\n\tgo fmt.Println(s)\n}",
+					Output:    "a\nb",
+					Unordered: true,
+				},
+			},
+			want: `{"name":"TestStruct","comment":"Test struct documentation","declaration":"type TestStruct struct {\n\tField1 string // Field1 documentation\n}","fields":[{"name":"Field1","type":"string","comment":"Field1 documentation","is_exported":true}],"methods":[{"name":"Method1","signature":"func (t *TestStruct) Method1() error","comment":"Method1 documentation"}],"examples":[{"name":"ExampleTestStruct","code":"for _, s := range []string{\"a\", \"b\"} {\n\tgo fmt.Println(s)\n}","output":"a\nb","unordered":true}]}`,
+		},
+		"struct with embedded field": {
+			sName: "File",
+			fields: []FieldDoc{
+				{
+					Name:       "Base",
+					Type:       "Base",
+					IsExported: true,
+					Embedded:   true,
+				},
+			},
+			methods: []MethodDoc{
+				{
+					Name:            "Touch",
+					Signature:       "func (b *Base) Touch()",
+					PromotedFrom:    "Base",
+					PointerReceiver: true,
+				},
+			},
+			want: `{"name":"File","comment":"","declaration":"","fields":[{"name":"Base","type":"Base","comment":"","is_exported":true,"embedded":true}],"methods":[{"name":"Touch","signature":"func (b *Base) Touch()","comment":"","promoted_from":"Base","pointer_receiver":true}]}`,
+		},
+		"struct with tags and layout": {
+			sName: "User",
+			fields: []FieldDoc{
+				{
+					Name:       "ID",
+					Type:       "int64",
+					IsExported: true,
+					Tag:        `json:"id,omitempty" db:"id"`,
+					Tags: []StructTagDoc{
+						{Key: "json", Value: "id,omitempty", Name: "id", Options: []string{"omitempty"}},
+						{Key: "db", Value: "id", Name: "id"},
+					},
+					Layout: &FieldLayoutDoc{Offset: 0, Size: 8, Align: 8},
+				},
+			},
+			methods: []MethodDoc{},
+			layout:  &StructLayoutDoc{Arch: "amd64", Size: 8, Align: 8},
+			want:    `{"name":"User","comment":"","declaration":"","fields":[{"name":"ID","type":"int64","comment":"","is_exported":true,"tag":"json:\"id,omitempty\" db:\"id\"","tags":[{"key":"json","value":"id,omitempty","name":"id","options":["omitempty"]},{"key":"db","value":"id","name":"id"}],"layout":{"offset":0,"size":8,"align":8}}],"methods":[],"layout":{"arch":"amd64","size":8,"align":8}}`,
 		},
 		"empty struct": {
 			sName:   "EmptyStruct",
 			comment: "",
 			fields:  []FieldDoc{},
 			methods: []MethodDoc{},
-			want:    `{"name":"EmptyStruct","comment":"","fields":[],"methods":[]}`,
+			want:    `{"name":"EmptyStruct","comment":"","declaration":"","fields":[],"methods":[]}`,
+		},
+		"generic struct": {
+			sName:   "List",
+			comment: "",
+			fields:  []FieldDoc{},
+			methods: []MethodDoc{},
+			typeParams: []TypeParamDoc{
+				{
+					Name:       "T",
+					Constraint: "cmp.Ordered",
+					TypeSet:    []string{"~int", "~string"},
+				},
+			},
+			instantiations: []InstantiationDoc{
+				{
+					TypeArgs: []string{"int"},
+					Type:     "github.com/example/list.List[int]",
+					Position: "main.go:10",
+					Count:    2,
+				},
+			},
+			want: `{"name":"List","comment":"","declaration":"","fields":[],"methods":[],"type_params":[{"name":"T","constraint":"cmp.Ordered","type_set":["~int","~string"]}],"instantiations":[{"type_args":["int"],"type":"github.com/example/list.List[int]","position":"main.go:10","count":2}]}`,
 		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			got := FormatStructDoc(tt.sName, tt.comment, tt.fields, tt.methods)
+			got := FormatStructDoc(tt.sName, tt.comment, tt.declaration, tt.fields, tt.methods, tt.layout, tt.examples, tt.typeParams, tt.instantiations)
 			var gotJSON, wantJSON interface{}
 			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
 				t.Errorf("FormatStructDoc() invalid JSON = %v", err)
@@ -192,15 +327,610 @@ func TestFormatStructDoc(t *testing.T) {
 	}
 }
 
+func TestFormatInterfaceDoc(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		iName           string
+		comment         string
+		declaration     string
+		methods         []InterfaceMethodDoc
+		embeddeds       []string
+		typeSet         []string
+		isConstraint    bool
+		implementations []ImplementationDoc
+		want            string
+	}{
+		"interface with methods and implementations": {
+			iName:       "ReadCloser",
+			comment:     "ReadCloser documentation",
+			declaration: "type ReadCloser interface {\n\tio.Reader\n\tClose() error\n}",
+			methods: []InterfaceMethodDoc{
+				{
+					Name:      "Close",
+					Signature: "func Close() error",
+					Comment:   "Close documentation",
+				},
+				{
+					Name:         "Read",
+					Signature:    "func Read(p []byte) (n int, err error)",
+					EmbeddedFrom: "io.Reader",
+				},
+			},
+			embeddeds: []string{"io.Reader"},
+			typeSet:   []string{},
+			implementations: []ImplementationDoc{
+				{
+					Name:       "File",
+					ImportPath: "github.com/example/fs",
+					Position:   "fs/file.go:10",
+					IsPointer:  true,
+				},
+			},
+			want: `{"name":"ReadCloser","comment":"ReadCloser documentation","declaration":"type ReadCloser interface {\n\tio.Reader\n\tClose() error\n}","methods":[{"name":"Close","signature":"func Close() error","comment":"Close documentation"},{"name":"Read","signature":"func Read(p []byte) (n int, err error)","comment":"","embedded_from":"io.Reader"}],"embeddeds":["io.Reader"],"type_set":[],"is_constraint":false,"implementations":[{"name":"File","import_path":"github.com/example/fs","position":"fs/file.go:10","is_pointer":true}]}`,
+		},
+		"constraint interface": {
+			iName:           "Number",
+			comment:         "",
+			methods:         []InterfaceMethodDoc{},
+			embeddeds:       []string{},
+			typeSet:         []string{"~int", "~float64"},
+			isConstraint:    true,
+			implementations: []ImplementationDoc{},
+			want:            `{"name":"Number","comment":"","declaration":"","methods":[],"embeddeds":[],"type_set":["~int","~float64"],"is_constraint":true,"implementations":[]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatInterfaceDoc(tt.iName, tt.comment, tt.declaration, tt.methods, tt.embeddeds, tt.typeSet, tt.isConstraint, tt.implementations, nil, nil, nil)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatInterfaceDoc() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatInterfaceDoc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTypeDoc(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tName        string
+		kind         string
+		comment      string
+		declaration  string
+		underlying   string
+		constants    []ConstDoc
+		constructors []ConstructorDoc
+		methods      []MethodDoc
+		want         string
+	}{
+		"enum type": {
+			tName:       "Mode",
+			kind:        "basic",
+			comment:     "Mode is a mode",
+			declaration: "type Mode int",
+			underlying:  "int",
+			constants: []ConstDoc{
+				{
+					Name:        "ModeRead",
+					Type:        "Mode",
+					Value:       "0",
+					Declaration: "const (\n\tModeRead Mode = iota\n)",
+				},
+			},
+			constructors: []ConstructorDoc{
+				{
+					Name:      "ParseMode",
+					Signature: "func ParseMode(s string) (Mode, error)",
+				},
+			},
+			methods: []MethodDoc{
+				{
+					Name:      "String",
+					Signature: "func (m Mode) String() string",
+				},
+			},
+			want: `{"name":"Mode","kind":"basic","comment":"Mode is a mode","declaration":"type Mode int","underlying":"int","constants":[{"name":"ModeRead","type":"Mode","value":"0","comment":"","declaration":"const (\n\tModeRead Mode = iota\n)"}],"constructors":[{"name":"ParseMode","signature":"func ParseMode(s string) (Mode, error)","comment":""}],"methods":[{"name":"String","signature":"func (m Mode) String() string","comment":""}]}`,
+		},
+		"alias": {
+			tName:        "IDs",
+			kind:         "alias",
+			declaration:  "type IDs = []string",
+			underlying:   "[]string",
+			constants:    []ConstDoc{},
+			constructors: []ConstructorDoc{},
+			methods:      []MethodDoc{},
+			want:         `{"name":"IDs","kind":"alias","comment":"","declaration":"type IDs = []string","underlying":"[]string","constants":[],"constructors":[],"methods":[]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatTypeDoc(tt.tName, tt.kind, tt.comment, tt.declaration, tt.underlying, tt.constants, tt.constructors, tt.methods, nil, nil)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatTypeDoc() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatTypeDoc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatImplementations(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tName           string
+		importPath      string
+		isInterface     bool
+		implementations []ImplementationDoc
+		interfaces      []ImplementationDoc
+		want            string
+	}{
+		"implementations of an interface": {
+			tName:       "Writer",
+			importPath:  "io",
+			isInterface: true,
+			implementations: []ImplementationDoc{
+				{
+					Name:       "Buffer",
+					ImportPath: "github.com/example/buf",
+					Position:   "buf/buffer.go:12",
+					IsPointer:  true,
+				},
+			},
+			want: `{"name":"Writer","import_path":"io","is_interface":true,"implementations":[{"name":"Buffer","import_path":"github.com/example/buf","position":"buf/buffer.go:12","is_pointer":true}]}`,
+		},
+		"interfaces implemented by a type": {
+			tName:      "Buffer",
+			importPath: "github.com/example/buf",
+			interfaces: []ImplementationDoc{
+				{
+					Name:       "Stringer",
+					ImportPath: "fmt",
+					Position:   "fmt/print.go:63",
+				},
+			},
+			want: `{"name":"Buffer","import_path":"github.com/example/buf","is_interface":false,"interfaces":[{"name":"Stringer","import_path":"fmt","position":"fmt/print.go:63","is_pointer":false}]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatImplementations(tt.tName, tt.importPath, tt.isInterface, tt.implementations, tt.interfaces)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatImplementations() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatImplementations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		symbol     string
+		kind       string
+		importPath string
+		position   string
+		references []ReferenceDoc
+		want       string
+	}{
+		"references in functions and at package level": {
+			symbol:     "New",
+			kind:       "func",
+			importPath: "github.com/example/store",
+			position:   "store/store.go:17",
+			references: []ReferenceDoc{
+				{ImportPath: "github.com/example/store", File: "store/store.go", Line: 14, Column: 15},
+				{ImportPath: "github.com/example/store_test", File: "store/store_test.go", Line: 10, Column: 13, Enclosing: "TestAdd"},
+			},
+			want: `{"name":"New","kind":"func","import_path":"github.com/example/store","position":"store/store.go:17","references":[{"import_path":"github.com/example/store","file":"store/store.go","line":14,"column":15},{"import_path":"github.com/example/store_test","file":"store/store_test.go","line":10,"column":13,"enclosing":"TestAdd"}]}`,
+		},
+		"no references": {
+			symbol:     "Unused",
+			kind:       "const",
+			importPath: "github.com/example/store",
+			position:   "store/store.go:3",
+			references: []ReferenceDoc{},
+			want:       `{"name":"Unused","kind":"const","import_path":"github.com/example/store","position":"store/store.go:3","references":[]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatReferences(tt.symbol, tt.kind, tt.importPath, tt.position, tt.references)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatReferences() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDependencyGraph(t *testing.T) {
+	t.Parallel()
+
+	packages := []GraphPackageDoc{
+		{ImportPath: "github.com/example/app"},
+		{ImportPath: "strings", IsDependency: true},
+	}
+	imports := []ImportEdgeDoc{
+		{From: "github.com/example/app", To: "strings"},
+	}
+
+	tests := map[string]struct {
+		pkg        string
+		transitive bool
+		cycles     [][]string
+		why        *DependencyPathDoc
+		violations []LayerViolationDoc
+		want       string
+	}{
+		"graph with path query": {
+			pkg:        "github.com/example/app",
+			transitive: true,
+			why:        &DependencyPathDoc{From: "github.com/example/app", To: "strings", Path: []string{"github.com/example/app", "strings"}},
+			violations: []LayerViolationDoc{},
+			want:       `{"package":"github.com/example/app","transitive":true,"packages":[{"import_path":"github.com/example/app","is_dependency":false},{"import_path":"strings","is_dependency":true}],"imports":[{"from":"github.com/example/app","to":"strings"}],"why":{"from":"github.com/example/app","to":"strings","path":["github.com/example/app","strings"]},"violations":[]}`,
+		},
+		"cycles and violations": {
+			cycles: [][]string{{"github.com/example/app", "strings"}},
+			violations: []LayerViolationDoc{
+				{From: "app", To: "strings", Reason: "no text processing", Package: "github.com/example/app", Imported: "strings", Path: []string{"github.com/example/app", "strings"}},
+			},
+			want: `{"transitive":false,"packages":[{"import_path":"github.com/example/app","is_dependency":false},{"import_path":"strings","is_dependency":true}],"imports":[{"from":"github.com/example/app","to":"strings"}],"cycles":[["github.com/example/app","strings"]],"violations":[{"from":"app","to":"strings","reason":"no text processing","package":"github.com/example/app","imported":"strings","path":["github.com/example/app","strings"]}]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatDependencyGraph(tt.pkg, tt.transitive, packages, imports, tt.cycles, tt.why, tt.violations)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatDependencyGraph() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatDependencyGraph() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyDiagrams(t *testing.T) {
+	t.Parallel()
+
+	packages := []GraphPackageDoc{
+		{ImportPath: "github.com/example/app"},
+		{ImportPath: "strings", IsDependency: true},
+	}
+	imports := []ImportEdgeDoc{
+		{From: "github.com/example/app", To: "strings"},
+	}
+
+	tests := map[string]struct {
+		write func(sb *strings.Builder, packages []GraphPackageDoc, imports []ImportEdgeDoc)
+		want  string
+	}{
+		"mermaid": {
+			write: writeMermaid,
+			want: `graph LR
+  n0["github.com/example/app"]
+  n1["strings"]
+  n0 --> n1
+  classDef dependency stroke-dasharray: 5 5
+  class n1 dependency
+`,
+		},
+		"dot": {
+			write: writeDOT,
+			want: `digraph imports {
+  rankdir=LR;
+  "github.com/example/app";
+  "strings" [style=dashed];
+  "github.com/example/app" -> "strings";
+}
+`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var sb strings.Builder
+			tt.write(&sb, packages, imports)
+			if got := sb.String(); got != tt.want {
+				t.Errorf("diagram = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		packages []PackageDiagnosticsDoc
+		want     string
+	}{
+		"package with errors": {
+			packages: []PackageDiagnosticsDoc{
+				{
+					ImportPath: "github.com/example/broken",
+					Errors: []PackageErrorDoc{
+						{Kind: "type", Position: "broken/broken.go:5:10", Message: "undefined: Duration"},
+						{Kind: "list", Message: "no required module provides package github.com/example/missing"},
+					},
+				},
+			},
+			want: `{"packages":[{"import_path":"github.com/example/broken","is_dependency":false,"errors":[{"kind":"type","position":"broken/broken.go:5:10","message":"undefined: Duration"},{"kind":"list","position":"","message":"no required module provides package github.com/example/missing"}]}]}`,
+		},
+		"no errors": {
+			packages: []PackageDiagnosticsDoc{},
+			want:     `{"packages":[]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatDiagnostics(tt.packages)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatDiagnostics() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatDiagnostics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCallers(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		function   string
+		importPath string
+		algorithm  string
+		depth      int
+		calls      []CallDoc
+		want       string
+	}{
+		"direct and dynamic callers": {
+			function:   "Square.Area",
+			importPath: "github.com/example/shape",
+			algorithm:  "cha",
+			depth:      2,
+			calls: []CallDoc{
+				{
+					Caller:   "github.com/example/shape.Total",
+					Callee:   "(github.com/example/shape.Square).Area",
+					Position: "shape/shape.go:20",
+					Dynamic:  true,
+					Depth:    1,
+				},
+			},
+			want: `{"function":"Square.Area","import_path":"github.com/example/shape","algorithm":"cha","depth":2,"calls":[{"caller":"github.com/example/shape.Total","callee":"(github.com/example/shape.Square).Area","position":"shape/shape.go:20","dynamic":true,"depth":1}]}`,
+		},
+		"no callers": {
+			function:   "main",
+			importPath: "github.com/example/cmd",
+			algorithm:  "static",
+			depth:      1,
+			calls:      []CallDoc{},
+			want:       `{"function":"main","import_path":"github.com/example/cmd","algorithm":"static","depth":1,"calls":[]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatCallers(tt.function, tt.importPath, tt.algorithm, tt.depth, tt.calls)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatCallers() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatCallers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSearchSymbols(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query   string
+		symbols []SymbolDoc
+		want    string
+	}{
+		"matching symbols": {
+			query: "pars",
+			symbols: []SymbolDoc{
+				{
+					Name:       "Parser",
+					Kind:       "struct",
+					ImportPath: "github.com/example/parser",
+					Summary:    "Parser parses files.",
+					Position:   "parser/parser.go:10",
+				},
+			},
+			want: `{"query":"pars","symbols":[{"name":"Parser","kind":"struct","import_path":"github.com/example/parser","summary":"Parser parses files.","position":"parser/parser.go:10"}]}`,
+		},
+		"symbol with signature": {
+			query: "NewParser",
+			symbols: []SymbolDoc{
+				{
+					Name:       "NewParser",
+					Kind:       "func",
+					ImportPath: "github.com/example/parser",
+					Signature:  "func NewParser() *Parser",
+					Summary:    "NewParser creates a Parser.",
+					Position:   "parser/parser.go:20",
+				},
+			},
+			want: `{"query":"NewParser","symbols":[{"name":"NewParser","kind":"func","import_path":"github.com/example/parser","signature":"func NewParser() *Parser","summary":"NewParser creates a Parser.","position":"parser/parser.go:20"}]}`,
+		},
+		"no symbols": {
+			query:   "nothing",
+			symbols: []SymbolDoc{},
+			want:    `{"query":"nothing","symbols":[]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatSearchSymbols(tt.query, tt.symbols)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatSearchSymbols() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatSearchSymbols() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSearchDocs(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query   string
+		matches []DocMatchDoc
+		want    string
+	}{
+		"matching documentation": {
+			query: "retry with backoff",
+			matches: []DocMatchDoc{
+				{
+					Name:       "Retry",
+					Kind:       "func",
+					ImportPath: "github.com/example/retry",
+					Snippet:    "Retry calls fn with exponential backoff.",
+					Position:   "retry/retry.go:8",
+					Score:      1.5,
+				},
+			},
+			want: `{"query":"retry with backoff","matches":[{"name":"Retry","kind":"func","import_path":"github.com/example/retry","snippet":"Retry calls fn with exponential backoff.","position":"retry/retry.go:8","score":1.5}]}`,
+		},
+		"no matches": {
+			query:   "nothing",
+			matches: []DocMatchDoc{},
+			want:    `{"query":"nothing","matches":[]}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatSearchDocs(tt.query, tt.matches)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatSearchDocs() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatSearchDocs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatFuncDoc(t *testing.T) {
 	t.Parallel()
 
 	tests := map[string]struct {
-		fName     string
-		signature string
-		comment   string
-		examples  []Example
-		want      string
+		fName      string
+		signature  string
+		comment    string
+		examples   []Example
+		typeParams []TypeParamDoc
+		want       string
 	}{
 		"function with examples": {
 			fName:     "TestFunc",
@@ -222,12 +952,25 @@ func TestFormatFuncDoc(t *testing.T) {
 			examples:  []Example{},
 			want:      `{"name":"EmptyFunc","signature":"func EmptyFunc()","comment":"","examples":[]}`,
 		},
+		"generic function": {
+			fName:     "Map",
+			signature: "func[T any](xs []T) []T",
+			comment:   "",
+			examples:  []Example{},
+			typeParams: []TypeParamDoc{
+				{
+					Name:       "T",
+					Constraint: "any",
+				},
+			},
+			want: `{"name":"Map","signature":"func[T any](xs []T) []T","comment":"","examples":[],"type_params":[{"name":"T","constraint":"any"}]}`,
+		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			got := FormatFuncDoc(tt.fName, tt.signature, tt.comment, tt.examples)
+			got := FormatFuncDoc(tt.fName, tt.signature, tt.comment, tt.examples, tt.typeParams, nil)
 			var gotJSON, wantJSON interface{}
 			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
 				t.Errorf("FormatFuncDoc() invalid JSON = %v", err)
@@ -284,7 +1027,7 @@ func TestFormatMethodDoc(t *testing.T) {
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			got := FormatMethodDoc(tt.receiverType, tt.mName, tt.signature, tt.comment, tt.examples)
+			got := FormatMethodDoc(tt.receiverType, tt.mName, tt.signature, tt.comment, tt.examples, nil)
 			var gotJSON, wantJSON interface{}
 			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
 				t.Errorf("FormatMethodDoc() invalid JSON = %v", err)
@@ -303,6 +1046,53 @@ func TestFormatMethodDoc(t *testing.T) {
 	}
 }
 
+func TestFormatSource(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		sName      string
+		kind       string
+		importPath string
+		file       string
+		startLine  int
+		endLine    int
+		source     string
+		want       string
+	}{
+		"function": {
+			sName:      "Double",
+			kind:       "func",
+			importPath: "github.com/example/shape",
+			file:       "shape/shape.go",
+			startLine:  30,
+			endLine:    32,
+			source:     "func Double(x float64) float64 {\n\treturn 2 * x\n}",
+			want:       `{"name":"Double","kind":"func","import_path":"github.com/example/shape","file":"shape/shape.go","start_line":30,"end_line":32,"source":"func Double(x float64) float64 {\n\treturn 2 * x\n}"}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatSource(tt.sName, tt.kind, tt.importPath, tt.file, tt.startLine, tt.endLine, tt.source)
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Errorf("FormatSource() invalid JSON = %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("want invalid JSON = %v", err)
+				return
+			}
+			gotStr, _ := json.Marshal(gotJSON)
+			wantStr, _ := json.Marshal(wantJSON)
+			if string(gotStr) != string(wantStr) {
+				t.Errorf("FormatSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatConstAndVarDoc(t *testing.T) {
 	t.Parallel()
 
@@ -314,20 +1104,22 @@ func TestFormatConstAndVarDoc(t *testing.T) {
 		"constants and variables": {
 			constants: []ConstDoc{
 				{
-					Name:    "TestConst",
-					Type:    "string",
-					Value:   "\"test\"",
-					Comment: "Test constant documentation",
+					Name:        "TestConst",
+					Type:        "string",
+					Value:       "\"test\"",
+					Comment:     "Test constant documentation",
+					Declaration: "const TestConst = \"test\"",
 				},
 			},
 			variables: []VarDoc{
 				{
-					Name:    "TestVar",
-					Type:    "int",
-					Comment: "Test variable documentation",
+					Name:        "TestVar",
+					Type:        "int",
+					Comment:     "Test variable documentation",
+					Declaration: "var TestVar int",
 				},
 			},
-			want: `{"constants":[{"name":"TestConst","type":"string","value":"\"test\"","comment":"Test constant documentation"}],"variables":[{"name":"TestVar","type":"int","comment":"Test variable documentation"}]}`,
+			want: `{"constants":[{"name":"TestConst","type":"string","value":"\"test\"","comment":"Test constant documentation","declaration":"const TestConst = \"test\""}],"variables":[{"name":"TestVar","type":"int","comment":"Test variable documentation","declaration":"var TestVar int"}]}`,
 		},
 		"empty constants and variables": {
 			constants: []ConstDoc{},
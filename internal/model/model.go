@@ -13,65 +13,222 @@ package model
 
 // PackageInfo represents information about a Go package
 type PackageInfo struct {
-	Name       string `json:"name"`        // Package name
-	ImportPath string `json:"import_path"` // Import path
-	Comment    string `json:"comment"`     // Package comment
+	Name         string `json:"name"`                  // Package name
+	ImportPath   string `json:"import_path"`           // Import path
+	Module       string `json:"module,omitempty"`      // Path of the served module the package belongs to, empty for dependencies
+	Comment      string `json:"comment"`               // Package comment
+	IsDependency bool   `json:"is_dependency"`         // Whether the package is a dependency rather than a local package
+	ErrorCount   int    `json:"error_count,omitempty"` // Number of errors reported while loading the package
 }
 
-// StructSummary represents a summary of a struct
-type StructSummary struct {
-	Name    string `json:"name"`    // Struct name
-	Comment string `json:"comment"` // Struct comment
+// ValueSummary represents a summary of a constant or variable declaration group
+type ValueSummary struct {
+	Names   []string `json:"names"`   // Names declared in the group
+	Comment string   `json:"comment"` // Group comment
 }
 
-// FuncSummary represents a summary of a function
+// FuncSummary represents a summary of a function or method
 type FuncSummary struct {
 	Name    string `json:"name"`    // Function name
 	Comment string `json:"comment"` // Function comment
 }
 
-// MethodSummary represents a summary of a method
-type MethodSummary struct {
-	ReceiverType string `json:"receiver_type"` // Receiver type
-	Name         string `json:"name"`          // Method name
-	Comment      string `json:"comment"`       // Method comment
+// TypeSummary represents a summary of a named type and the declarations grouped under it
+type TypeSummary struct {
+	Name         string         `json:"name"`                   // Type name
+	Kind         string         `json:"kind"`                   // Kind of the underlying type, or "alias" for type aliases
+	Comment      string         `json:"comment"`                // Type comment
+	Constants    []ValueSummary `json:"constants,omitempty"`    // Constant groups of the type
+	Variables    []ValueSummary `json:"variables,omitempty"`    // Variable groups of the type
+	Constructors []FuncSummary  `json:"constructors,omitempty"` // Functions returning the type
+	Methods      []FuncSummary  `json:"methods,omitempty"`      // Methods of the type
 }
 
 // FieldDoc represents documentation for a struct field
 type FieldDoc struct {
-	Name       string `json:"name"`        // Field name
-	Type       string `json:"type"`        // Field type
-	Comment    string `json:"comment"`     // Field comment
-	IsExported bool   `json:"is_exported"` // Whether the field is exported
+	Name       string          `json:"name"`               // Field name
+	Type       string          `json:"type"`               // Field type
+	Comment    string          `json:"comment"`            // Field comment
+	IsExported bool            `json:"is_exported"`        // Whether the field is exported
+	Embedded   bool            `json:"embedded,omitempty"` // Whether the field is embedded
+	Tag        string          `json:"tag,omitempty"`      // Raw struct tag
+	Tags       []StructTagDoc  `json:"tags,omitempty"`     // Key-value pairs of the struct tag
+	Layout     *FieldLayoutDoc `json:"layout,omitempty"`   // Memory layout of the field
+}
+
+// StructTagDoc represents a key:"value" pair of a struct tag
+type StructTagDoc struct {
+	Key     string   `json:"key"`               // Tag key, such as json or db
+	Value   string   `json:"value"`             // Unquoted tag value
+	Name    string   `json:"name"`              // Value up to the first comma
+	Options []string `json:"options,omitempty"` // Comma-separated options following the name
+}
+
+// FieldLayoutDoc represents the memory layout of a struct field
+type FieldLayoutDoc struct {
+	Offset int64 `json:"offset"` // Offset of the field in the struct, in bytes
+	Size   int64 `json:"size"`   // Size of the field, in bytes
+	Align  int64 `json:"align"`  // Alignment of the field, in bytes
+}
+
+// StructLayoutDoc represents the memory layout of a struct
+type StructLayoutDoc struct {
+	Arch  string `json:"arch"`  // GOARCH the layout is computed for
+	Size  int64  `json:"size"`  // Size of the struct, in bytes
+	Align int64  `json:"align"` // Alignment of the struct, in bytes
 }
 
 // MethodDoc represents documentation for a method
 type MethodDoc struct {
-	Name      string `json:"name"`      // Method name
-	Signature string `json:"signature"` // Method signature
-	Comment   string `json:"comment"`   // Method comment
+	Name            string `json:"name"`                       // Method name
+	Signature       string `json:"signature"`                  // Method signature
+	Comment         string `json:"comment"`                    // Method comment
+	PromotedFrom    string `json:"promoted_from,omitempty"`    // Embedded type the method is promoted from
+	PointerReceiver bool   `json:"pointer_receiver,omitempty"` // Whether the method is only in the method set of the pointer type
+}
+
+// ConstructorDoc represents documentation for a function returning a type
+type ConstructorDoc struct {
+	Name      string `json:"name"`      // Function name
+	Signature string `json:"signature"` // Function signature
+	Comment   string `json:"comment"`   // Function comment
+}
+
+// InterfaceMethodDoc represents documentation for an interface method
+type InterfaceMethodDoc struct {
+	Name         string `json:"name"`                    // Method name
+	Signature    string `json:"signature"`               // Method signature
+	Comment      string `json:"comment"`                 // Method comment
+	EmbeddedFrom string `json:"embedded_from,omitempty"` // Embedded interface that declares the method
+}
+
+// ImplementationDoc represents a named type on one side of an implementation relationship
+type ImplementationDoc struct {
+	Name       string `json:"name"`        // Type name
+	ImportPath string `json:"import_path"` // Import path of the type's package
+	Position   string `json:"position"`    // Source position of the type declaration
+	IsPointer  bool   `json:"is_pointer"`  // Whether only the pointer type takes part in the relationship
+}
+
+// CallDoc represents a call from one function to another
+type CallDoc struct {
+	Caller   string `json:"caller"`   // Calling function, qualified with its package path
+	Callee   string `json:"callee"`   // Called function, qualified with its package path
+	Position string `json:"position"` // Source position of the call site
+	Dynamic  bool   `json:"dynamic"`  // Whether the call is made through an interface or a function value
+	Depth    int    `json:"depth"`    // Distance from the queried function, 1 for direct calls
+}
+
+// ReferenceDoc represents a use of a symbol
+type ReferenceDoc struct {
+	ImportPath string `json:"import_path"`         // Import path of the package containing the reference
+	File       string `json:"file"`                // File containing the reference
+	Line       int    `json:"line"`                // Line of the reference
+	Column     int    `json:"column"`              // Column of the reference, in bytes
+	Enclosing  string `json:"enclosing,omitempty"` // Function or method containing the reference
+}
+
+// GraphPackageDoc represents a package in an import graph
+type GraphPackageDoc struct {
+	ImportPath   string `json:"import_path"`   // Import path of the package
+	IsDependency bool   `json:"is_dependency"` // Whether the package is outside the root directory
+}
+
+// ImportEdgeDoc represents an import of a package by another
+type ImportEdgeDoc struct {
+	From string `json:"from"` // Import path of the importing package
+	To   string `json:"to"`   // Import path of the imported package
+}
+
+// DependencyPathDoc represents the shortest import chain between two packages
+type DependencyPathDoc struct {
+	From string   `json:"from"` // Import path of the depending package
+	To   string   `json:"to"`   // Import path of the package it depends on
+	Path []string `json:"path"` // Import chain from From to To, empty if From does not depend on To
+}
+
+// LayerViolationDoc represents a dependency forbidden by a layering rule
+type LayerViolationDoc struct {
+	From     string   `json:"from"`             // Pattern of the packages the rule applies to
+	To       string   `json:"to"`               // Pattern of the packages they must not depend on
+	Reason   string   `json:"reason,omitempty"` // Why the dependency is forbidden
+	Package  string   `json:"package"`          // Import path of the package breaking the rule
+	Imported string   `json:"imported"`         // Import path of the forbidden package
+	Path     []string `json:"path"`             // Import chain from Package to Imported
+}
+
+// PackageErrorDoc represents an error reported while loading a package
+type PackageErrorDoc struct {
+	Kind     string `json:"kind"`     // Error kind: list, parse, type, or unknown
+	Position string `json:"position"` // Source position of the error, empty if unknown
+	Message  string `json:"message"`  // Error message
+}
+
+// PackageDiagnosticsDoc represents the errors of a package
+type PackageDiagnosticsDoc struct {
+	ImportPath   string            `json:"import_path"`   // Import path of the package
+	IsDependency bool              `json:"is_dependency"` // Whether the package is a dependency rather than a local package
+	Errors       []PackageErrorDoc `json:"errors"`        // Errors in the order they were reported
+}
+
+// SymbolDoc represents a symbol found by a symbol search
+type SymbolDoc struct {
+	Name       string `json:"name"`                // Symbol name, qualified with the type name for methods and fields
+	Kind       string `json:"kind"`                // Symbol kind
+	ImportPath string `json:"import_path"`         // Import path of the symbol's package
+	Signature  string `json:"signature,omitempty"` // One-line signature of the symbol
+	Summary    string `json:"summary"`             // First sentence of the symbol comment
+	Position   string `json:"position"`            // Source position of the declaration
+}
+
+// DocMatchDoc represents a documented symbol matching a full-text query
+type DocMatchDoc struct {
+	Name       string  `json:"name"`        // Symbol name, qualified with the type name for methods and fields
+	Kind       string  `json:"kind"`        // Symbol kind
+	ImportPath string  `json:"import_path"` // Import path of the symbol's package
+	Snippet    string  `json:"snippet"`     // Sentence of the comment that best matches the query
+	Position   string  `json:"position"`    // Source position of the declaration
+	Score      float64 `json:"score"`       // Relevance score
+}
+
+// TypeParamDoc represents documentation for a type parameter
+type TypeParamDoc struct {
+	Name       string   `json:"name"`               // Type parameter name
+	Constraint string   `json:"constraint"`         // Constraint of the type parameter
+	TypeSet    []string `json:"type_set,omitempty"` // Types permitted by the constraint
+}
+
+// InstantiationDoc represents a use of a generic function or type with concrete type arguments
+type InstantiationDoc struct {
+	TypeArgs []string `json:"type_args"` // Type arguments
+	Type     string   `json:"type"`      // Instantiated type or signature
+	Position string   `json:"position"`  // Source position of the first use
+	Count    int      `json:"count"`     // Number of uses
 }
 
 // Example represents an example for a function or method
 type Example struct {
-	Name   string `json:"name"`   // Example name
-	Code   string `json:"code"`   // Example code
-	Output string `json:"output"` // Example output
+	Name      string `json:"name"`                // Example function name
+	Code      string `json:"code"`                // Example code
+	Output    string `json:"output"`              // Expected output
+	Unordered bool   `json:"unordered,omitempty"` // Whether the output lines may appear in any order
 }
 
 // ConstDoc represents documentation for a constant
 type ConstDoc struct {
-	Name    string `json:"name"`    // Constant name
-	Type    string `json:"type"`    // Constant type
-	Value   string `json:"value"`   // Constant value
-	Comment string `json:"comment"` // Constant comment
+	Name        string `json:"name"`        // Constant name
+	Type        string `json:"type"`        // Constant type
+	Value       string `json:"value"`       // Constant value
+	Comment     string `json:"comment"`     // Constant comment
+	Declaration string `json:"declaration"` // Go declaration of the constant's declaration group
 }
 
 // VarDoc represents documentation for a variable
 type VarDoc struct {
-	Name    string `json:"name"`    // Variable name
-	Type    string `json:"type"`    // Variable type
-	Comment string `json:"comment"` // Variable comment
+	Name        string `json:"name"`        // Variable name
+	Type        string `json:"type"`        // Variable type
+	Comment     string `json:"comment"`     // Variable comment
+	Declaration string `json:"declaration"` // Go declaration of the variable's declaration group
 }
 
 // ListPackagesResponse represents the response for list_packages
@@ -81,35 +238,140 @@ type ListPackagesResponse struct {
 
 // InspectPackageResponse represents the response for inspect_package
 type InspectPackageResponse struct {
-	Package   PackageInfo     `json:"package"`
-	Structs   []StructSummary `json:"structs"`
-	Functions []FuncSummary   `json:"functions"`
-	Methods   []MethodSummary `json:"methods"`
+	Package   PackageInfo    `json:"package"`
+	Constants []ValueSummary `json:"constants"`
+	Variables []ValueSummary `json:"variables"`
+	Functions []FuncSummary  `json:"functions"`
+	Types     []TypeSummary  `json:"types"`
+	Examples  []Example      `json:"examples,omitempty"`
 }
 
 // StructDocResponse represents the response for get_doc_struct
 type StructDocResponse struct {
-	Name    string      `json:"name"`
-	Comment string      `json:"comment"`
-	Fields  []FieldDoc  `json:"fields"`
-	Methods []MethodDoc `json:"methods"`
+	Name           string             `json:"name"`
+	Comment        string             `json:"comment"`
+	Declaration    string             `json:"declaration"`
+	Fields         []FieldDoc         `json:"fields"`
+	Methods        []MethodDoc        `json:"methods"`
+	Layout         *StructLayoutDoc   `json:"layout,omitempty"`
+	Examples       []Example          `json:"examples,omitempty"`
+	TypeParams     []TypeParamDoc     `json:"type_params,omitempty"`
+	Instantiations []InstantiationDoc `json:"instantiations,omitempty"`
+}
+
+// InterfaceDocResponse represents the response for get_interface_doc
+type InterfaceDocResponse struct {
+	Name            string               `json:"name"`
+	Comment         string               `json:"comment"`
+	Declaration     string               `json:"declaration"`
+	Methods         []InterfaceMethodDoc `json:"methods"`
+	Embeddeds       []string             `json:"embeddeds"`
+	TypeSet         []string             `json:"type_set"`
+	IsConstraint    bool                 `json:"is_constraint"`
+	Implementations []ImplementationDoc  `json:"implementations"`
+	Examples        []Example            `json:"examples,omitempty"`
+	TypeParams      []TypeParamDoc       `json:"type_params,omitempty"`
+	Instantiations  []InstantiationDoc   `json:"instantiations,omitempty"`
+}
+
+// TypeDocResponse represents the response for get_type_doc
+type TypeDocResponse struct {
+	Name         string           `json:"name"`
+	Kind         string           `json:"kind"`
+	Comment      string           `json:"comment"`
+	Declaration  string           `json:"declaration"`
+	Underlying   string           `json:"underlying"`
+	Constants    []ConstDoc       `json:"constants"`
+	Constructors []ConstructorDoc `json:"constructors"`
+	Methods      []MethodDoc      `json:"methods"`
+	Examples     []Example        `json:"examples,omitempty"`
+	TypeParams   []TypeParamDoc   `json:"type_params,omitempty"`
+}
+
+// ImplementationsResponse represents the response for find_implementations
+type ImplementationsResponse struct {
+	Name            string              `json:"name"`
+	ImportPath      string              `json:"import_path"`
+	IsInterface     bool                `json:"is_interface"`
+	Implementations []ImplementationDoc `json:"implementations,omitempty"`
+	Interfaces      []ImplementationDoc `json:"interfaces,omitempty"`
+}
+
+// CallsResponse represents the response for get_callers and get_callees
+type CallsResponse struct {
+	Function   string    `json:"function"`
+	ImportPath string    `json:"import_path"`
+	Algorithm  string    `json:"algorithm"`
+	Depth      int       `json:"depth"`
+	Calls      []CallDoc `json:"calls"`
+}
+
+// ReferencesResponse represents the response for find_references
+type ReferencesResponse struct {
+	Name       string         `json:"name"`
+	Kind       string         `json:"kind"`
+	ImportPath string         `json:"import_path"`
+	Position   string         `json:"position"`
+	References []ReferenceDoc `json:"references"`
+}
+
+// DependencyGraphResponse represents the response for get_dependency_graph
+type DependencyGraphResponse struct {
+	Package    string              `json:"package,omitempty"`
+	Transitive bool                `json:"transitive"`
+	Packages   []GraphPackageDoc   `json:"packages"`
+	Imports    []ImportEdgeDoc     `json:"imports"`
+	Cycles     [][]string          `json:"cycles,omitempty"`
+	Why        *DependencyPathDoc  `json:"why,omitempty"`
+	Violations []LayerViolationDoc `json:"violations"`
+}
+
+// DiagnosticsResponse represents the response for get_diagnostics
+type DiagnosticsResponse struct {
+	Packages []PackageDiagnosticsDoc `json:"packages"`
+}
+
+// SearchSymbolsResponse represents the response for search_symbols
+type SearchSymbolsResponse struct {
+	Query   string      `json:"query"`
+	Symbols []SymbolDoc `json:"symbols"`
+}
+
+// SearchDocsResponse represents the response for search_docs
+type SearchDocsResponse struct {
+	Query   string        `json:"query"`
+	Matches []DocMatchDoc `json:"matches"`
 }
 
 // FuncDocResponse represents the response for get_doc_func
 type FuncDocResponse struct {
-	Name      string    `json:"name"`
-	Signature string    `json:"signature"`
-	Comment   string    `json:"comment"`
-	Examples  []Example `json:"examples"`
+	Name           string             `json:"name"`
+	Signature      string             `json:"signature"`
+	Comment        string             `json:"comment"`
+	Examples       []Example          `json:"examples"`
+	TypeParams     []TypeParamDoc     `json:"type_params,omitempty"`
+	Instantiations []InstantiationDoc `json:"instantiations,omitempty"`
 }
 
 // MethodDocResponse represents the response for get_doc_method
 type MethodDocResponse struct {
-	ReceiverType string    `json:"receiver_type"`
-	Name         string    `json:"name"`
-	Signature    string    `json:"signature"`
-	Comment      string    `json:"comment"`
-	Examples     []Example `json:"examples"`
+	ReceiverType string         `json:"receiver_type"`
+	Name         string         `json:"name"`
+	Signature    string         `json:"signature"`
+	Comment      string         `json:"comment"`
+	Examples     []Example      `json:"examples"`
+	TypeParams   []TypeParamDoc `json:"type_params,omitempty"`
+}
+
+// SourceResponse represents the response for get_source
+type SourceResponse struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	ImportPath string `json:"import_path"`
+	File       string `json:"file"`
+	StartLine  int    `json:"start_line"`
+	EndLine    int    `json:"end_line"`
+	Source     string `json:"source"`
 }
 
 // ConstAndVarResponse represents the response for get_doc_const_and_var
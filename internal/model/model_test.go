@@ -19,7 +19,7 @@ func TestPackageInfoJSON(t *testing.T) {
 				ImportPath: "github.com/example/testpkg",
 				Comment:    "This is a test package",
 			},
-			want: `{"name":"testpkg","import_path":"github.com/example/testpkg","comment":"This is a test package"}`,
+			want: `{"name":"testpkg","import_path":"github.com/example/testpkg","comment":"This is a test package","is_dependency":false}`,
 		},
 		"empty comment": {
 			pkg: PackageInfo{
@@ -27,7 +27,16 @@ func TestPackageInfoJSON(t *testing.T) {
 				ImportPath: "github.com/example/emptypkg",
 				Comment:    "",
 			},
-			want: `{"name":"emptypkg","import_path":"github.com/example/emptypkg","comment":""}`,
+			want: `{"name":"emptypkg","import_path":"github.com/example/emptypkg","comment":"","is_dependency":false}`,
+		},
+		"dependency package": {
+			pkg: PackageInfo{
+				Name:         "http",
+				ImportPath:   "net/http",
+				Comment:      "Package http provides HTTP client and server implementations.",
+				IsDependency: true,
+			},
+			want: `{"name":"http","import_path":"net/http","comment":"Package http provides HTTP client and server implementations.","is_dependency":true}`,
 		},
 	}
 
@@ -80,7 +89,7 @@ func TestListPackagesResponseJSON(t *testing.T) {
 					},
 				},
 			},
-			want: `{"packages":[{"name":"pkg1","import_path":"github.com/example/pkg1","comment":"Package 1"},{"name":"pkg2","import_path":"github.com/example/pkg2","comment":"Package 2"}]}`,
+			want: `{"packages":[{"name":"pkg1","import_path":"github.com/example/pkg1","comment":"Package 1","is_dependency":false},{"name":"pkg2","import_path":"github.com/example/pkg2","comment":"Package 2","is_dependency":false}]}`,
 		},
 		"empty packages": {
 			response: ListPackagesResponse{
@@ -126,8 +135,9 @@ func TestStructDocResponseJSON(t *testing.T) {
 	}{
 		"struct with fields and methods": {
 			response: StructDocResponse{
-				Name:    "TestStruct",
-				Comment: "Test struct documentation",
+				Name:        "TestStruct",
+				Comment:     "Test struct documentation",
+				Declaration: "type TestStruct struct {\n\tField1 string\n}",
 				Fields: []FieldDoc{
 					{
 						Name:       "Field1",
@@ -144,7 +154,7 @@ func TestStructDocResponseJSON(t *testing.T) {
 					},
 				},
 			},
-			want: `{"name":"TestStruct","comment":"Test struct documentation","fields":[{"name":"Field1","type":"string","comment":"Field1 documentation","is_exported":true}],"methods":[{"name":"Method1","signature":"func (t *TestStruct) Method1() error","comment":"Method1 documentation"}]}`,
+			want: `{"name":"TestStruct","comment":"Test struct documentation","declaration":"type TestStruct struct {\n\tField1 string\n}","fields":[{"name":"Field1","type":"string","comment":"Field1 documentation","is_exported":true}],"methods":[{"name":"Method1","signature":"func (t *TestStruct) Method1() error","comment":"Method1 documentation"}]}`,
 		},
 	}
 
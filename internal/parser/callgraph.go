@@ -0,0 +1,301 @@
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/static"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Call graph algorithms
+const (
+	CallGraphStatic = "static" // Static calls only
+	CallGraphCHA    = "cha"    // Class hierarchy analysis: dynamic calls may reach every implementation
+	CallGraphVTA    = "vta"    // Variable type analysis: dynamic calls reach the types that flow to them
+)
+
+// Call represents a call from one function to another
+type Call struct {
+	Caller   string // Calling function, qualified with its package path
+	Callee   string // Called function, qualified with its package path
+	Position string // Source position of the call site
+	Dynamic  bool   // Whether the call is made through an interface or a function value
+	Depth    int    // Distance from the queried function, 1 for direct calls
+}
+
+// callGraphs holds the call graphs of the loaded packages, built on first use for each algorithm.
+type callGraphs struct {
+	generation uint64          // Package map generation the program was built from
+	scope      string          // Package the program was built around in lazy mode, empty if built from every package
+	prog       *ssa.Program    // SSA form of the loaded packages and their dependencies
+	local      map[string]bool // Paths of the packages under the root directory
+
+	mu     sync.Mutex
+	graphs map[string]*callgraph.Graph
+}
+
+// GetCallers returns the calls to the specified function or method, and the calls to its callers
+// up to depth levels. funcName is a function name, or "Type.Method" for methods.
+// Callers are only followed through the packages under the root directory.
+func (p *Parser) GetCallers(pkgPath, funcName, algorithm string, depth int) ([]Call, error) {
+	return p.walkCallGraph(pkgPath, funcName, algorithm, depth, true)
+}
+
+// GetCallees returns the calls made by the specified function or method, and the calls made by
+// its callees up to depth levels. funcName is a function name, or "Type.Method" for methods.
+// Callees are only followed through the packages under the root directory.
+func (p *Parser) GetCallees(pkgPath, funcName, algorithm string, depth int) ([]Call, error) {
+	return p.walkCallGraph(pkgPath, funcName, algorithm, depth, false)
+}
+
+// walkCallGraph walks the call graph from the specified function, towards callers if up is true.
+func (p *Parser) walkCallGraph(pkgPath, funcName, algorithm string, depth int, up bool) ([]Call, error) {
+	cgs := p.callGraphs(pkgPath)
+	graph, err := cgs.graph(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := lookupFunc(cgs.prog, pkgPath, funcName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Instances of a generic function are separate nodes
+	var start []*callgraph.Node
+	for fn, node := range graph.Nodes {
+		if fn != nil && (fn.Object() == obj || fn.Origin() != nil && fn.Origin().Object() == obj) {
+			start = append(start, node)
+		}
+	}
+
+	// Synthetic wrappers and thunks are followed without being reported, so each queued
+	// node carries the function the reported calls are attributed to
+	type item struct {
+		node *callgraph.Node
+		as   *ssa.Function
+	}
+	calls := make([]Call, 0)
+	reported := make(map[Call]bool)
+	visited := make(map[*callgraph.Node]bool)
+	frontier := make([]item, 0, len(start))
+	for _, node := range start {
+		visited[node] = true
+		frontier = append(frontier, item{node: node, as: node.Func})
+	}
+	for d := 1; d <= depth && len(frontier) > 0; d++ {
+		var next []item
+		for len(frontier) > 0 {
+			it := frontier[0]
+			frontier = frontier[1:]
+			edges := it.node.Out
+			if up {
+				edges = it.node.In
+			}
+			for _, edge := range edges {
+				other := edge.Callee
+				if up {
+					other = edge.Caller
+				}
+				if other.Func == nil {
+					continue
+				}
+				if other.Func.Synthetic != "" {
+					if !visited[other] {
+						visited[other] = true
+						frontier = append(frontier, item{node: other, as: it.as})
+					}
+					continue
+				}
+
+				call := Call{
+					Caller:   it.as.String(),
+					Callee:   other.Func.String(),
+					Position: p.position(cgs.prog.Fset, edge.Pos()),
+					Dynamic:  edge.Site != nil && edge.Site.Common().StaticCallee() == nil,
+					Depth:    d,
+				}
+				if up {
+					call.Caller, call.Callee = other.Func.String(), it.as.String()
+				}
+				// A call through an interface may reach the same method directly and through a wrapper
+				if !reported[call] {
+					reported[call] = true
+					calls = append(calls, call)
+				}
+
+				if !visited[other] && cgs.local[funcPkgPath(other.Func)] {
+					visited[other] = true
+					next = append(next, item{node: other, as: other.Func})
+				}
+			}
+		}
+		frontier = next
+	}
+
+	sort.Slice(calls, func(i, j int) bool {
+		a, b := calls[i], calls[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		if a.Caller != b.Caller {
+			return a.Caller < b.Caller
+		}
+		if a.Callee != b.Callee {
+			return a.Callee < b.Callee
+		}
+		return a.Position < b.Position
+	})
+	return calls, nil
+}
+
+// callGraphs returns the call graphs for the current packages, building the program if needed.
+// In lazy mode, the program is built around the package at pkgPath.
+func (p *Parser) callGraphs(pkgPath string) *callGraphs {
+	var scope string
+	if p.opts.Lazy {
+		scope = pkgPath
+	}
+
+	p.mu.RLock()
+	cgs, generation := p.calls, p.sources
+	p.mu.RUnlock()
+	if cgs != nil && cgs.generation == generation && cgs.scope == scope {
+		return cgs
+	}
+
+	cgs = p.buildCallGraphs(generation, scope)
+
+	p.mu.Lock()
+	// Keep the program only if no reload happened while building it
+	if p.sources == generation {
+		if p.calls != nil && p.calls.generation == generation && p.calls.scope == scope {
+			cgs = p.calls
+		} else {
+			p.calls = cgs
+		}
+	}
+	p.mu.Unlock()
+
+	return cgs
+}
+
+// buildCallGraphs builds the SSA form of the loaded packages and their dependencies.
+// Packages reloaded separately do not share a file set and type universe with the others,
+// so in that case every package is loaded again together. In lazy mode, scope is loaded with the
+// packages it imports and the packages importing it under the root directory instead, which are
+// the packages the calls from and to it can go through.
+func (p *Parser) buildCallGraphs(generation uint64, scope string) *callGraphs {
+	pkgs := p.GetAllPackages()
+	patterns := []string{"./..."}
+	reload := false
+	if scope != "" {
+		patterns = p.relatedPackages(scope)
+		reload = true
+	}
+	for _, pkg := range pkgs {
+		if pkg.Fset != pkgs[0].Fset {
+			reload = true
+			break
+		}
+	}
+	if reload {
+		if loaded, err := packages.Load(loadConfig(p.rootDir), patterns...); err == nil {
+			pkgs = loaded
+		}
+	}
+
+	local := make(map[string]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		local[pkg.PkgPath] = true
+	}
+	prog, _ := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	return &callGraphs{
+		generation: generation,
+		scope:      scope,
+		prog:       prog,
+		local:      local,
+		graphs:     make(map[string]*callgraph.Graph),
+	}
+}
+
+// graph returns the call graph computed with the specified algorithm, building it if needed.
+func (cgs *callGraphs) graph(algorithm string) (*callgraph.Graph, error) {
+	if algorithm == "" {
+		algorithm = CallGraphCHA
+	}
+
+	cgs.mu.Lock()
+	defer cgs.mu.Unlock()
+
+	if graph, ok := cgs.graphs[algorithm]; ok {
+		return graph, nil
+	}
+	var graph *callgraph.Graph
+	switch algorithm {
+	case CallGraphStatic:
+		graph = static.CallGraph(cgs.prog)
+	case CallGraphCHA:
+		graph = cha.CallGraph(cgs.prog)
+	case CallGraphVTA:
+		graph = vta.CallGraph(ssautil.AllFunctions(cgs.prog), cha.CallGraph(cgs.prog))
+	default:
+		return nil, fmt.Errorf("unknown call graph algorithm: %s", algorithm)
+	}
+	cgs.graphs[algorithm] = graph
+	return graph, nil
+}
+
+// lookupFunc returns the function or concrete method named funcName in the package of prog
+// with the specified path. Methods are named "Type.Method".
+func lookupFunc(prog *ssa.Program, pkgPath, funcName string) (*types.Func, error) {
+	pkg := prog.ImportedPackage(pkgPath)
+	if pkg == nil {
+		return nil, fmt.Errorf("package not found in the call graph: %s", pkgPath)
+	}
+	scope := pkg.Pkg.Scope()
+
+	typeName, methodName, isMethod := strings.Cut(funcName, ".")
+	if !isMethod {
+		fn, ok := scope.Lookup(funcName).(*types.Func)
+		if !ok {
+			return nil, fmt.Errorf("function not found: %s in package %s", funcName, pkgPath)
+		}
+		return fn, nil
+	}
+
+	tn, ok := scope.Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type not found: %s in package %s", typeName, pkgPath)
+	}
+	obj, _, _ := types.LookupFieldOrMethod(tn.Type(), true, pkg.Pkg, methodName)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("method not found: %s in package %s", funcName, pkgPath)
+	}
+	if types.IsInterface(tn.Type()) {
+		return nil, fmt.Errorf("%s is an interface method, which is only called through its implementations", funcName)
+	}
+	return fn, nil
+}
+
+// funcPkgPath returns the path of the package declaring fn, or of its generic origin.
+func funcPkgPath(fn *ssa.Function) string {
+	if fn.Origin() != nil {
+		fn = fn.Origin()
+	}
+	if fn.Pkg == nil {
+		return ""
+	}
+	return fn.Pkg.Pkg.Path()
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetCallers(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"shape/shape.go": `package shape
+
+// Shape has an area.
+type Shape interface {
+	Area() int
+}
+
+// Square is a shape.
+type Square struct{ Side int }
+
+// Area returns the area of the square.
+func (s Square) Area() int { return mul(s.Side, s.Side) }
+
+func mul(a, b int) int { return a * b }
+
+// Total sums the areas of shapes.
+func Total(shapes []Shape) int {
+	total := 0
+	for _, s := range shapes {
+		total += s.Area()
+	}
+	return total
+}
+
+// Report returns the total area of a square.
+func Report() int {
+	return Total([]Shape{Square{Side: 2}})
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		funcName  string
+		algorithm string
+		depth     int
+		want      []Call
+	}{
+		"direct callers": {
+			funcName:  "mul",
+			algorithm: CallGraphStatic,
+			depth:     1,
+			want: []Call{
+				{Caller: "(example.com/m/shape.Square).Area", Callee: "example.com/m/shape.mul", Position: "shape/shape.go:12", Depth: 1},
+			},
+		},
+		"dynamic callers": {
+			funcName:  "mul",
+			algorithm: CallGraphCHA,
+			depth:     2,
+			want: []Call{
+				{Caller: "(example.com/m/shape.Square).Area", Callee: "example.com/m/shape.mul", Position: "shape/shape.go:12", Depth: 1},
+				{Caller: "example.com/m/shape.Total", Callee: "(example.com/m/shape.Square).Area", Position: "shape/shape.go:20", Dynamic: true, Depth: 2},
+			},
+		},
+		"static graph has no dynamic callers": {
+			funcName:  "Square.Area",
+			algorithm: CallGraphStatic,
+			depth:     3,
+			want:      []Call{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := p.GetCallers("example.com/m/shape", tt.funcName, tt.algorithm, tt.depth)
+			if err != nil {
+				t.Fatalf("GetCallers() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCallers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := p.GetCallers("example.com/m/shape", "Shape.Area", CallGraphCHA, 1); err == nil {
+		t.Error("GetCallers() of an interface method succeeded, want an error")
+	}
+}
+
+func TestParser_GetCallees(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"greet/greet.go": `package greet
+
+import "strings"
+
+// Greet greets name.
+func Greet(name string) string {
+	return "Hello, " + title(name)
+}
+
+func title(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := p.GetCallees("example.com/m/greet", "Greet", CallGraphVTA, 2)
+	if err != nil {
+		t.Fatalf("GetCallees() error = %v", err)
+	}
+	// Callees outside the root directory are reported but not followed
+	want := []Call{
+		{Caller: "example.com/m/greet.Greet", Callee: "example.com/m/greet.title", Position: "greet/greet.go:7", Depth: 1},
+		{Caller: "example.com/m/greet.title", Callee: "strings.ToUpper", Position: "greet/greet.go:11", Depth: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCallees() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// commentIndex maps the objects declared in a package to their doc comments.
+type commentIndex map[types.Object]string
+
+// GetComment returns the doc comment of obj, which must be declared in pkg.
+// If the declaration has no doc comment, its line comment is returned, and for constants,
+// variables and types declared in a group, the comment of the group.
+func (p *Parser) GetComment(pkg *packages.Package, obj types.Object) string {
+	p.mu.RLock()
+	index, ok := p.comments[pkg]
+	p.mu.RUnlock()
+	if !ok {
+		// Packages are indexed when loaded, so this only happens for packages replaced by a reload
+		index = buildCommentIndex(pkg)
+	}
+	return index[origin(obj)]
+}
+
+// indexComments returns comment indexes for pkgs, reusing the indexes in current.
+func indexComments(current map[*packages.Package]commentIndex, pkgs []*packages.Package) map[*packages.Package]commentIndex {
+	indexes := make(map[*packages.Package]commentIndex, len(pkgs))
+	for _, pkg := range pkgs {
+		if index, ok := current[pkg]; ok {
+			indexes[pkg] = index
+			continue
+		}
+		indexes[pkg] = buildCommentIndex(pkg)
+	}
+	return indexes
+}
+
+// buildCommentIndex maps every object declared at package level in pkg, and every field and
+// interface method of the types declared there, to its comment.
+// Objects are resolved from their declaring identifiers through TypesInfo.Defs.
+func buildCommentIndex(pkg *packages.Package) commentIndex {
+	index := make(commentIndex)
+	if pkg.TypesInfo == nil {
+		return index
+	}
+	add := func(ident *ast.Ident, groups ...*ast.CommentGroup) {
+		if ident == nil {
+			return
+		}
+		obj := pkg.TypesInfo.Defs[ident]
+		if obj == nil {
+			return
+		}
+		for _, group := range groups {
+			if text := strings.TrimSpace(group.Text()); text != "" {
+				index[obj] = text
+				return
+			}
+		}
+	}
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				add(d.Name, d.Doc)
+			case *ast.GenDecl:
+				// The doc comment of an ungrouped declaration is attached to the GenDecl
+				var declDoc *ast.CommentGroup
+				if !d.Lparen.IsValid() {
+					declDoc = d.Doc
+				}
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						add(s.Name, s.Doc, declDoc, s.Comment, d.Doc)
+						indexFields(s.Type, add)
+					case *ast.ValueSpec:
+						for _, name := range s.Names {
+							add(name, s.Doc, declDoc, s.Comment, d.Doc)
+						}
+					}
+				}
+			}
+		}
+	}
+	return index
+}
+
+// indexFields adds the fields and interface methods declared in the type expression typ,
+// including those of nested struct and interface types.
+func indexFields(typ ast.Expr, add func(ident *ast.Ident, groups ...*ast.CommentGroup)) {
+	addFields := func(fields *ast.FieldList) {
+		for _, field := range fields.List {
+			if len(field.Names) == 0 {
+				add(embeddedIdent(field.Type), field.Doc, field.Comment)
+			}
+			for _, name := range field.Names {
+				add(name, field.Doc, field.Comment)
+			}
+		}
+	}
+	ast.Inspect(typ, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.StructType:
+			addFields(t.Fields)
+		case *ast.InterfaceType:
+			addFields(t.Methods)
+		case *ast.FuncType:
+			// Parameters and results are not documented
+			return false
+		}
+		return true
+	})
+}
+
+// embeddedIdent returns the identifier that TypesInfo.Defs maps to an embedded field,
+// which is the name of the embedded type.
+func embeddedIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			return e.Sel
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// origin returns the generic object that obj is an instance of, or obj itself.
+func origin(obj types.Object) types.Object {
+	switch o := obj.(type) {
+	case *types.Func:
+		return o.Origin()
+	case *types.Var:
+		return o.Origin()
+	}
+	return obj
+}
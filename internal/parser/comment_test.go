@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"go/types"
+	"testing"
+)
+
+func TestParser_GetComment(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"pet/pet.go": `package pet
+
+import "fmt"
+
+// Dog is a dog.
+type Dog struct {
+	// Name of the dog
+	Name string
+	fmt.Stringer // Embedded stringer
+}
+
+// String returns the name of the dog.
+func (d Dog) String() string { return d.Name }
+
+// Cat is a cat.
+type Cat struct {
+	Name string // Name of the cat
+}
+
+// String returns the name of the cat.
+func (c Cat) String() string { return c.Name }
+
+// Sizes of pets.
+const (
+	Small = iota // Small pet
+	Large
+)
+
+// Box holds a value.
+type Box[T any] struct {
+	// Value in the box
+	Value T
+}
+
+// Get returns the value.
+func (b Box[T]) Get() T { return b.Value }
+
+var IntBox Box[int]
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	pkg, err := p.GetPackage("example.com/m/pet")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	scope := pkg.Types.Scope()
+	lookup := func(typeName, name string) types.Object {
+		obj, _, _ := types.LookupFieldOrMethod(scope.Lookup(typeName).Type(), false, pkg.Types, name)
+		return obj
+	}
+	intBox := scope.Lookup("IntBox").Type()
+	instance := func(name string) types.Object {
+		obj, _, _ := types.LookupFieldOrMethod(intBox, false, pkg.Types, name)
+		return obj
+	}
+
+	tests := map[string]struct {
+		obj  types.Object
+		want string
+	}{
+		"type":                      {obj: scope.Lookup("Dog"), want: "Dog is a dog."},
+		"method of first type":      {obj: lookup("Dog", "String"), want: "String returns the name of the dog."},
+		"method of second type":     {obj: lookup("Cat", "String"), want: "String returns the name of the cat."},
+		"field with doc comment":    {obj: lookup("Dog", "Name"), want: "Name of the dog"},
+		"field with line comment":   {obj: lookup("Cat", "Name"), want: "Name of the cat"},
+		"embedded field":            {obj: lookup("Dog", "Stringer"), want: "Embedded stringer"},
+		"constant with own comment": {obj: scope.Lookup("Small"), want: "Small pet"},
+		"constant in a group":       {obj: scope.Lookup("Large"), want: "Sizes of pets."},
+		"method of instance":        {obj: instance("Get"), want: "Get returns the value."},
+		"field of instance":         {obj: instance("Value"), want: "Value in the box"},
+		"undocumented":              {obj: scope.Lookup("IntBox"), want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.obj == nil {
+				t.Fatal("object not found")
+			}
+			if got := p.GetComment(pkg, tt.obj); got != tt.want {
+				t.Errorf("GetComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// qualifier returns a types.Qualifier that omits the current package and qualifies
+// other packages by name, the way they are usually referred to in source code.
+func qualifier(pkg *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if pkg != nil && other.Path() == pkg.Path() {
+			return ""
+		}
+		return other.Name()
+	}
+}
+
+// findDecl returns the file and the top-level declaration that declare the object at pos.
+// For type, const and var declarations, the spec declaring the object is returned as well.
+func findDecl(pkg *packages.Package, pos token.Pos) (*ast.File, ast.Decl, ast.Spec) {
+	if !pos.IsValid() {
+		return nil, nil, nil
+	}
+	for _, file := range pkg.Syntax {
+		if pos < file.Pos() || pos >= file.End() {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if pos < decl.Pos() || pos >= decl.End() {
+				continue
+			}
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Name.Pos() == pos {
+					return file, d, nil
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						if s.Name.Pos() == pos {
+							return file, d, s
+						}
+					case *ast.ValueSpec:
+						for _, name := range s.Names {
+							if name.Pos() == pos {
+								return file, d, s
+							}
+						}
+					}
+				}
+			}
+			return nil, nil, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// declaration returns the declaration of obj as gofmt'd Go source, as go doc shows it.
+// Function bodies and doc comments are removed, a type declared in a group is shown
+// on its own, and a constant or variable is shown with its whole declaration group.
+// If the source is not available, the declaration is built from the type information.
+func declaration(pkg *packages.Package, obj types.Object) string {
+	_, decl, spec := findDecl(pkg, obj.Pos())
+
+	var node ast.Node
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		fd := *d
+		fd.Doc = nil
+		fd.Body = nil
+		node = &fd
+	case *ast.GenDecl:
+		gd := *d
+		gd.Doc = nil
+		if ts, ok := spec.(*ast.TypeSpec); ok {
+			spec := *ts
+			spec.Type = trimUnexported(spec.Type)
+			if d.Lparen.IsValid() {
+				spec.Doc = nil
+				spec.Comment = nil
+				gd.TokPos = spec.Pos()
+				gd.Lparen = token.NoPos
+				gd.Rparen = token.NoPos
+			}
+			gd.Specs = []ast.Spec{&spec}
+		}
+		node = &gd
+	}
+
+	if node != nil {
+		// Only the comments attached to the nodes are printed, so that the comments of removed
+		// fields and methods are removed with them
+		var sb strings.Builder
+		if err := format.Node(&sb, pkg.Fset, node); err == nil {
+			return sb.String()
+		}
+	}
+	return typesDeclaration(obj)
+}
+
+// trimUnexported returns a copy of a struct or interface type without its unexported fields
+// and methods, ending with a comment noting them as go doc does. Embedded types are named
+// by their type name. Other types are returned unchanged.
+func trimUnexported(expr ast.Expr) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.StructType:
+		st := *t
+		st.Fields = trimFields(t.Fields, "fields")
+		return &st
+	case *ast.InterfaceType:
+		it := *t
+		it.Methods = trimFields(t.Methods, "methods")
+		return &it
+	}
+	return expr
+}
+
+// trimFields returns fields without its unexported entries, described as what in the comment
+// replacing them. Type constraints embedded in interfaces are kept.
+func trimFields(fields *ast.FieldList, what string) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	trimmed := false
+	list := make([]*ast.Field, 0, len(fields.List))
+	for _, field := range fields.List {
+		names := field.Names
+		if len(names) == 0 {
+			if ident := embeddedIdent(field.Type); ident != nil {
+				names = []*ast.Ident{ident}
+			}
+		}
+		if slices.ContainsFunc(names, func(name *ast.Ident) bool { return !name.IsExported() }) {
+			trimmed = true
+			continue
+		}
+		list = append(list, field)
+	}
+	if !trimmed {
+		return fields
+	}
+
+	// The printer places the comment right before the closing brace
+	list = append(list, &ast.Field{
+		Type:    &ast.Ident{NamePos: fields.Closing - 1},
+		Comment: &ast.CommentGroup{List: []*ast.Comment{{Text: "// Has unexported " + what + "."}}},
+	})
+	return &ast.FieldList{Opening: fields.Opening, List: list, Closing: fields.Closing}
+}
+
+// typesDeclaration builds the declaration of obj from its type information.
+func typesDeclaration(obj types.Object) string {
+	qf := qualifier(obj.Pkg())
+	switch obj := obj.(type) {
+	case *types.Func:
+		sig := obj.Type().(*types.Signature)
+		var sb strings.Builder
+		sb.WriteString("func ")
+		// Methods of interfaces are shown as functions, as go doc does
+		if recv := sig.Recv(); recv != nil && !types.IsInterface(recv.Type()) {
+			sb.WriteString("(")
+			if recv.Name() != "" {
+				sb.WriteString(recv.Name() + " ")
+			}
+			sb.WriteString(types.TypeString(recv.Type(), qf))
+			sb.WriteString(") ")
+		}
+		sb.WriteString(obj.Name())
+		sb.WriteString(strings.TrimPrefix(types.TypeString(sig, qf), "func"))
+		return sb.String()
+	case *types.TypeName:
+		return "type " + obj.Name() + " " + types.TypeString(obj.Type().Underlying(), qf)
+	case *types.Const:
+		return "const " + obj.Name() + " " + types.TypeString(obj.Type(), qf) + " = " + obj.Val().String()
+	case *types.Var:
+		return "var " + obj.Name() + " " + types.TypeString(obj.Type(), qf)
+	}
+	return types.ObjectString(obj, qf)
+}
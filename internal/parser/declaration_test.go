@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParser_Declarations(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"store/store.go": `package store
+
+import (
+	"context"
+	"io"
+)
+
+// Kind is a kind of entry.
+type Kind int
+
+// Kinds of entries.
+const (
+	KindFile Kind = iota // regular file
+	KindDir              // directory
+)
+
+// ErrClosed is returned after Close.
+var ErrClosed = io.ErrClosedPipe
+
+type (
+	// Store stores entries.
+	Store struct {
+		Name string // Name of the store
+		w    io.Writer
+	}
+
+	// Getter gets entries.
+	Getter interface {
+		Get(ctx context.Context, key string) (*Store, error)
+	}
+
+	// Closer closes a store.
+	Closer interface {
+		Close() error
+		closeOnce()
+	}
+)
+
+// Open opens a store.
+func Open(ctx context.Context, w io.Writer) (*Store, error) {
+	return &Store{w: w}, nil
+}
+
+// Get returns the entry.
+func (s *Store) Get(ctx context.Context, key string) (*Store, error) {
+	return s, nil
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	const pkgPath = "example.com/m/store"
+
+	t.Run("function", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetFuncInfo(pkgPath, "Open")
+		if err != nil {
+			t.Fatalf("GetFuncInfo() error = %v", err)
+		}
+		if want := "func Open(ctx context.Context, w io.Writer) (*Store, error)"; info.Signature != want {
+			t.Errorf("Signature = %q, want %q", info.Signature, want)
+		}
+	})
+
+	t.Run("method", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetMethodInfo(pkgPath, "Store", "Get")
+		if err != nil {
+			t.Fatalf("GetMethodInfo() error = %v", err)
+		}
+		if want := "func (s *Store) Get(ctx context.Context, key string) (*Store, error)"; info.Signature != want {
+			t.Errorf("Signature = %q, want %q", info.Signature, want)
+		}
+	})
+
+	t.Run("struct in a group", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetStructInfo(pkgPath, "Store")
+		if err != nil {
+			t.Fatalf("GetStructInfo() error = %v", err)
+		}
+		// Unexported fields are hidden as go doc does
+		want := "type Store struct {\n\tName string // Name of the store\n\n\t// Has unexported fields.\n}"
+		if info.Declaration != want {
+			t.Errorf("Declaration = %q, want %q", info.Declaration, want)
+		}
+		if want := "io.Writer"; info.Fields[1].Type != want {
+			t.Errorf("Fields[1].Type = %q, want %q", info.Fields[1].Type, want)
+		}
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetInterfaceInfo(pkgPath, "Getter")
+		if err != nil {
+			t.Fatalf("GetInterfaceInfo() error = %v", err)
+		}
+		want := "type Getter interface {\n\tGet(ctx context.Context, key string) (*Store, error)\n}"
+		if info.Declaration != want {
+			t.Errorf("Declaration = %q, want %q", info.Declaration, want)
+		}
+		if want := "func Get(ctx context.Context, key string) (*Store, error)"; info.Methods[0].Signature != want {
+			t.Errorf("Methods[0].Signature = %q, want %q", info.Methods[0].Signature, want)
+		}
+	})
+
+	t.Run("interface with unexported methods", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetInterfaceInfo(pkgPath, "Closer")
+		if err != nil {
+			t.Fatalf("GetInterfaceInfo() error = %v", err)
+		}
+		want := "type Closer interface {\n\tClose() error\n\n\t// Has unexported methods.\n}"
+		if info.Declaration != want {
+			t.Errorf("Declaration = %q, want %q", info.Declaration, want)
+		}
+	})
+
+	t.Run("constants and variables", func(t *testing.T) {
+		t.Parallel()
+
+		constants, variables, err := p.GetConstAndVarInfo(pkgPath)
+		if err != nil {
+			t.Fatalf("GetConstAndVarInfo() error = %v", err)
+		}
+		wantConst := "const (\n\tKindFile Kind = iota // regular file\n\tKindDir              // directory\n)"
+		for _, c := range constants {
+			if c.Declaration != wantConst {
+				t.Errorf("%s Declaration = %q, want %q", c.Name, c.Declaration, wantConst)
+			}
+			if c.Type != "Kind" {
+				t.Errorf("%s Type = %q, want %q", c.Name, c.Type, "Kind")
+			}
+		}
+		if len(variables) != 1 {
+			t.Fatalf("len(variables) = %d, want 1", len(variables))
+		}
+		if want := "var ErrClosed = io.ErrClosedPipe"; variables[0].Declaration != want {
+			t.Errorf("Declaration = %q, want %q", variables[0].Declaration, want)
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ImportGraph represents the import graph of packages
+type ImportGraph struct {
+	Packages   []GraphPackage   // Packages in the graph, sorted by path
+	Imports    []ImportEdge     // Imports between the packages, sorted by importing and imported path
+	Cycles     [][]string       // Import cycles, each sorted by path
+	Violations []LayerViolation // Layering rules broken by the packages under the root directory
+}
+
+// GraphPackage represents a package in an import graph
+type GraphPackage struct {
+	Path         string // Package path
+	IsDependency bool   // Whether the package is outside the root directory
+}
+
+// ImportEdge represents an import of a package by another
+type ImportEdge struct {
+	From string // Path of the importing package
+	To   string // Path of the imported package
+}
+
+// LayerRule forbids the packages matching From from depending on the packages matching To.
+// Patterns are import paths or trailing path elements, such as internal/model, and match the
+// packages below them as well when they end with "/...".
+type LayerRule struct {
+	From   string // Pattern of the packages the rule applies to
+	To     string // Pattern of the packages they must not depend on
+	Reason string // Why the dependency is forbidden
+}
+
+// LayerViolation represents a dependency forbidden by a layering rule
+type LayerViolation struct {
+	Rule     LayerRule // Broken rule
+	Package  string    // Path of the package breaking the rule
+	Imported string    // Path of the forbidden package
+	Path     []string  // Shortest import chain from Package to Imported
+}
+
+// GetImportGraph returns the import graph of the specified package, or of all packages under
+// the root directory if pkgPath is empty. Only direct imports are included unless transitive is set,
+// and packages outside the root directory are left out unless includeDeps is set.
+// Dependencies forbidden by rules are reported for the packages under the root directory in the graph.
+func (p *Parser) GetImportGraph(pkgPath string, transitive, includeDeps bool, rules []LayerRule) (*ImportGraph, error) {
+	imports, local := p.importMap()
+	return importGraph(imports, local, pkgPath, transitive, includeDeps, rules)
+}
+
+// importGraph builds the import graph of GetImportGraph from the imports of every package
+// and the paths of the packages under the root directories.
+func importGraph(imports map[string][]string, local map[string]bool, pkgPath string, transitive, includeDeps bool, rules []LayerRule) (*ImportGraph, error) {
+	var start []string
+	if pkgPath != "" {
+		if _, ok := imports[pkgPath]; !ok {
+			return nil, fmt.Errorf("package not found: %s", pkgPath)
+		}
+		start = []string{pkgPath}
+	} else {
+		for path := range local {
+			start = append(start, path)
+		}
+		sort.Strings(start)
+	}
+	inGraph := func(path string) bool {
+		return includeDeps || local[path]
+	}
+
+	// Collect the packages, following imports past the starting packages only for transitive graphs
+	nodes := make(map[string]bool)
+	expanded := make(map[string]bool)
+	var edges []ImportEdge
+	queue := start
+	for _, path := range start {
+		nodes[path] = true
+	}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if expanded[path] {
+			continue
+		}
+		expanded[path] = true
+		for _, imported := range imports[path] {
+			if !inGraph(imported) {
+				continue
+			}
+			edges = append(edges, ImportEdge{From: path, To: imported})
+			if !nodes[imported] {
+				nodes[imported] = true
+				if transitive {
+					queue = append(queue, imported)
+				}
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+
+	graph := &ImportGraph{
+		Packages:   make([]GraphPackage, 0, len(nodes)),
+		Imports:    edges,
+		Cycles:     importCycles(nodes, edges),
+		Violations: make([]LayerViolation, 0),
+	}
+	if graph.Imports == nil {
+		graph.Imports = make([]ImportEdge, 0)
+	}
+	for path := range nodes {
+		graph.Packages = append(graph.Packages, GraphPackage{Path: path, IsDependency: !local[path]})
+	}
+	sort.Slice(graph.Packages, func(i, j int) bool {
+		return graph.Packages[i].Path < graph.Packages[j].Path
+	})
+
+	for _, pkg := range graph.Packages {
+		if pkg.IsDependency {
+			continue
+		}
+		for _, rule := range rules {
+			if !matchPackagePattern(rule.From, pkg.Path) {
+				continue
+			}
+			for _, chain := range importChains(imports, pkg.Path, func(path string) bool { return matchPackagePattern(rule.To, path) }) {
+				graph.Violations = append(graph.Violations, LayerViolation{
+					Rule:     rule,
+					Package:  pkg.Path,
+					Imported: chain[len(chain)-1],
+					Path:     chain,
+				})
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// FindImportPath returns the shortest import chain from the package from to the package to,
+// through the loaded packages and their dependencies. It returns nil if from does not depend on to.
+func (p *Parser) FindImportPath(from, to string) ([]string, error) {
+	imports, _ := p.importMap()
+	if _, ok := imports[from]; !ok {
+		return nil, fmt.Errorf("package not found: %s", from)
+	}
+	if _, ok := imports[to]; !ok {
+		return nil, fmt.Errorf("package not found: %s", to)
+	}
+	chains := importChains(imports, from, func(path string) bool { return path == to })
+	if len(chains) == 0 {
+		return nil, nil
+	}
+	return chains[0], nil
+}
+
+// importMap returns the sorted imports of every loaded package and dependency,
+// and the paths of the packages under the root directory.
+func (p *Parser) importMap() (map[string][]string, map[string]bool) {
+	pkgs := p.GetAllPackages()
+	local := make(map[string]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		local[pkg.PkgPath] = true
+	}
+
+	imports := make(map[string][]string)
+	queue := pkgs
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if _, ok := imports[pkg.PkgPath]; ok {
+			continue
+		}
+		paths := make([]string, 0, len(pkg.Imports))
+		for _, imported := range pkg.Imports {
+			paths = append(paths, imported.PkgPath)
+			queue = append(queue, imported)
+		}
+		sort.Strings(paths)
+		imports[pkg.PkgPath] = paths
+	}
+	return imports, local
+}
+
+// importChains returns the shortest import chain from the package from to each package it
+// depends on, directly or transitively, for which match returns true.
+func importChains(imports map[string][]string, from string, match func(path string) bool) [][]string {
+	parent := map[string]string{from: ""}
+	queue := []string{from}
+	var chains [][]string
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		for _, imported := range imports[path] {
+			if _, ok := parent[imported]; ok {
+				continue
+			}
+			parent[imported] = path
+			queue = append(queue, imported)
+			if !match(imported) {
+				continue
+			}
+			chain := []string{imported}
+			for p := path; p != ""; p = parent[p] {
+				chain = append(chain, p)
+			}
+			for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+				chain[i], chain[j] = chain[j], chain[i]
+			}
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
+
+// importCycles returns the strongly connected components of the graph that form import cycles,
+// found with Tarjan's algorithm. Go does not compile packages in a cycle, but the loader
+// still reports their imports.
+func importCycles(nodes map[string]bool, edges []ImportEdge) [][]string {
+	adjacent := make(map[string][]string)
+	for _, e := range edges {
+		adjacent[e.From] = append(adjacent[e.From], e.To)
+	}
+	paths := make([]string, 0, len(nodes))
+	for path := range nodes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	var visit func(path string)
+	visit = func(path string) {
+		index[path] = len(index)
+		lowlink[path] = index[path]
+		stack = append(stack, path)
+		onStack[path] = true
+		for _, next := range adjacent[path] {
+			if _, ok := index[next]; !ok {
+				visit(next)
+				lowlink[path] = min(lowlink[path], lowlink[next])
+			} else if onStack[next] {
+				lowlink[path] = min(lowlink[path], index[next])
+			}
+		}
+		if lowlink[path] != index[path] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == path {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, path := range paths {
+		if _, ok := index[path]; !ok {
+			visit(path)
+		}
+	}
+	return cycles
+}
+
+// matchPackagePattern reports whether the package path matches pattern, which is an import path
+// or its trailing path elements, optionally followed by "/..." to match the packages below it.
+func matchPackagePattern(pattern, path string) bool {
+	base, tree := strings.CutSuffix(pattern, "/...")
+	if path == base || strings.HasSuffix(path, "/"+base) {
+		return true
+	}
+	return tree && (strings.HasPrefix(path, base+"/") || strings.Contains(path, "/"+base+"/"))
+}
@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetImportGraph(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"internal/handler/handler.go": `package handler
+
+import "example.com/m/internal/model"
+
+// Handle handles a request.
+func Handle() model.Response { return model.Response{} }
+`,
+		"internal/model/model.go": `package model
+
+import "example.com/m/internal/util"
+
+// Response is a response.
+type Response struct{ Body string }
+
+var _ = util.Trim
+`,
+		"internal/util/util.go": `package util
+
+import (
+	"strings"
+
+	"example.com/m/internal/handler/status"
+)
+
+// Trim trims s.
+func Trim(s string) string { return strings.TrimSpace(s) + status.OK }
+`,
+		"internal/handler/status/status.go": `package status
+
+// OK is the OK status.
+const OK = "ok"
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	rules := []LayerRule{
+		{From: "internal/model", To: "internal/handler/...", Reason: "models do not depend on handlers"},
+		{From: "internal/util", To: "internal/model"},
+	}
+
+	tests := map[string]struct {
+		pkgPath        string
+		transitive     bool
+		includeDeps    bool
+		wantPackages   []GraphPackage
+		wantImports    []ImportEdge
+		wantViolations []LayerViolation
+	}{
+		"direct imports of a package": {
+			pkgPath: "example.com/m/internal/model",
+			wantPackages: []GraphPackage{
+				{Path: "example.com/m/internal/model"},
+				{Path: "example.com/m/internal/util"},
+			},
+			wantImports: []ImportEdge{
+				{From: "example.com/m/internal/model", To: "example.com/m/internal/util"},
+			},
+			wantViolations: []LayerViolation{
+				{
+					Rule:     rules[0],
+					Package:  "example.com/m/internal/model",
+					Imported: "example.com/m/internal/handler/status",
+					Path:     []string{"example.com/m/internal/model", "example.com/m/internal/util", "example.com/m/internal/handler/status"},
+				},
+			},
+		},
+		"transitive imports with dependencies": {
+			pkgPath:     "example.com/m/internal/util",
+			transitive:  true,
+			includeDeps: true,
+			wantPackages: []GraphPackage{
+				{Path: "example.com/m/internal/handler/status"},
+				{Path: "example.com/m/internal/util"},
+				{Path: "strings", IsDependency: true},
+			},
+			wantImports: []ImportEdge{
+				{From: "example.com/m/internal/util", To: "example.com/m/internal/handler/status"},
+				{From: "example.com/m/internal/util", To: "strings"},
+			},
+			wantViolations: []LayerViolation{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			graph, err := p.GetImportGraph(tt.pkgPath, tt.transitive, tt.includeDeps, rules)
+			if err != nil {
+				t.Fatalf("GetImportGraph() error = %v", err)
+			}
+			// The imports of the standard library depend on the Go version
+			var packages []GraphPackage
+			for _, pkg := range graph.Packages {
+				if !pkg.IsDependency || pkg.Path == "strings" {
+					packages = append(packages, pkg)
+				}
+			}
+			var imports []ImportEdge
+			for _, edge := range graph.Imports {
+				if !containsPackage(graph.Packages, edge.From, true) {
+					imports = append(imports, edge)
+				}
+			}
+			if !reflect.DeepEqual(packages, tt.wantPackages) {
+				t.Errorf("Packages = %+v, want %+v", packages, tt.wantPackages)
+			}
+			if !reflect.DeepEqual(imports, tt.wantImports) {
+				t.Errorf("Imports = %+v, want %+v", imports, tt.wantImports)
+			}
+			if !reflect.DeepEqual(graph.Violations, tt.wantViolations) {
+				t.Errorf("Violations = %+v, want %+v", graph.Violations, tt.wantViolations)
+			}
+		})
+	}
+
+	path, err := p.FindImportPath("example.com/m/internal/handler", "strings")
+	if err != nil {
+		t.Fatalf("FindImportPath() error = %v", err)
+	}
+	wantPath := []string{"example.com/m/internal/handler", "example.com/m/internal/model", "example.com/m/internal/util", "strings"}
+	if !reflect.DeepEqual(path, wantPath) {
+		t.Errorf("FindImportPath() = %v, want %v", path, wantPath)
+	}
+	if path, err := p.FindImportPath("example.com/m/internal/util", "example.com/m/internal/model"); err != nil || path != nil {
+		t.Errorf("FindImportPath() of an independent package = %v, %v, want nil", path, err)
+	}
+}
+
+// containsPackage reports whether packages contains the package with the specified path
+// and dependency flag.
+func containsPackage(packages []GraphPackage, path string, isDependency bool) bool {
+	for _, pkg := range packages {
+		if pkg.Path == path && pkg.IsDependency == isDependency {
+			return true
+		}
+	}
+	return false
+}
+
+func TestImportCycles(t *testing.T) {
+	t.Parallel()
+
+	nodes := map[string]bool{"a": true, "b": true, "c": true, "d": true}
+	edges := []ImportEdge{
+		{From: "a", To: "b"},
+		{From: "b", To: "c"},
+		{From: "c", To: "a"},
+		{From: "c", To: "d"},
+	}
+	want := [][]string{{"a", "b", "c"}}
+	if got := importCycles(nodes, edges); !reflect.DeepEqual(got, want) {
+		t.Errorf("importCycles() = %v, want %v", got, want)
+	}
+	if got := importCycles(nodes, edges[1:]); got != nil {
+		t.Errorf("importCycles() of an acyclic graph = %v, want nil", got)
+	}
+}
+
+func TestMatchPackagePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		"import path":              {pattern: "net/http", path: "net/http", want: true},
+		"trailing elements":        {pattern: "internal/model", path: "example.com/m/internal/model", want: true},
+		"partial element":          {pattern: "model", path: "example.com/m/internal/datamodel", want: false},
+		"subpackage without dots":  {pattern: "internal/model", path: "example.com/m/internal/model/sub", want: false},
+		"subpackage with dots":     {pattern: "internal/model/...", path: "example.com/m/internal/model/sub", want: true},
+		"package itself with dots": {pattern: "internal/model/...", path: "example.com/m/internal/model", want: true},
+		"prefix with dots":         {pattern: "net/...", path: "net/http", want: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := matchPackagePattern(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("matchPackagePattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// GetPackage returns a package by its package path.
+// Packages under the root directory are looked up first, then their dependencies,
+// including the standard library. Other packages are loaded on first request from
+// the module cache or GOROOT without accessing the network.
+// In lazy mode, packages under the root directory are type-checked on first request.
+// Returns an error if the package is not found.
+func (p *Parser) GetPackage(pkgPath string) (*packages.Package, error) {
+	p.mu.RLock()
+	pkg, ok := p.pkgs[pkgPath]
+	p.mu.RUnlock()
+	if ok {
+		p.touch(pkgPath)
+		return pkg, nil
+	}
+	if p.isListed(pkgPath) {
+		return p.loadLocal(pkgPath)
+	}
+
+	if pkg, ok := p.dependencies()[pkgPath]; ok {
+		return pkg, nil
+	}
+
+	p.mu.RLock()
+	pkg, ok = p.extra[pkgPath]
+	p.mu.RUnlock()
+	if ok {
+		return pkg, nil
+	}
+
+	pkg, err := p.loadDependency(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	index := buildCommentIndex(pkg)
+
+	p.mu.Lock()
+	p.extra[pkgPath] = pkg
+	p.comments[pkg] = index
+	p.mu.Unlock()
+
+	return pkg, nil
+}
+
+// GetDependencyPackages returns the packages that are not under the root directory
+// but have been loaded as dependencies or on request, sorted by package path.
+func (p *Parser) GetDependencyPackages() []*packages.Package {
+	deps := p.dependencies()
+
+	p.mu.RLock()
+	result := make([]*packages.Package, 0, len(deps)+len(p.extra))
+	for path, pkg := range p.extra {
+		if _, ok := deps[path]; !ok && p.pkgs[path] == nil {
+			result = append(result, pkg)
+		}
+	}
+	p.mu.RUnlock()
+
+	for _, pkg := range deps {
+		result = append(result, pkg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PkgPath < result[j].PkgPath
+	})
+	return result
+}
+
+// IsLocal reports whether the package is under the root directory.
+func (p *Parser) IsLocal(pkgPath string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, ok := p.pkgs[pkgPath]
+	if !ok {
+		_, ok = p.lazy.listed[pkgPath]
+	}
+	return ok
+}
+
+// dependencies returns the transitive dependencies of the loaded packages by package path,
+// excluding the loaded packages themselves.
+func (p *Parser) dependencies() map[string]*packages.Package {
+	p.mu.RLock()
+	deps, depsGeneration, generation := p.deps, p.depsGeneration, p.generation
+	p.mu.RUnlock()
+	if deps != nil && depsGeneration == generation {
+		return deps
+	}
+
+	deps = make(map[string]*packages.Package)
+	list := make([]*packages.Package, 0)
+	for _, pkg := range withDependencies(p.GetAllPackages()) {
+		if !p.IsLocal(pkg.PkgPath) {
+			deps[pkg.PkgPath] = pkg
+			list = append(list, pkg)
+		}
+	}
+	p.mu.RLock()
+	indexes := indexComments(p.comments, list)
+	p.mu.RUnlock()
+
+	p.mu.Lock()
+	// Keep the map only if no reload happened while building it
+	if p.generation == generation {
+		p.deps = deps
+		p.depsGeneration = generation
+		for pkg, index := range indexes {
+			p.comments[pkg] = index
+		}
+	}
+	p.mu.Unlock()
+
+	return deps
+}
+
+// loadDependency loads a package that is not a dependency of the loaded packages.
+// The network is never accessed, so only packages in GOROOT or the module cache can be loaded.
+func (p *Parser) loadDependency(pkgPath string) (*packages.Package, error) {
+	cfg := loadConfig(p.rootDir)
+	cfg.Env = append(os.Environ(), "GOPROXY=off")
+
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package %s: %w", pkgPath, err)
+	}
+	if len(pkgs) != 1 || len(pkgs[0].GoFiles) == 0 || pkgs[0].Types == nil {
+		return nil, fmt.Errorf("package not found: %s", pkgPath)
+	}
+	return pkgs[0], nil
+}
+
+// withDependencies returns pkgs and all of their transitive dependencies.
+func withDependencies(pkgs []*packages.Package) []*packages.Package {
+	var result []*packages.Package
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		result = append(result, pkg)
+	})
+	return result
+}
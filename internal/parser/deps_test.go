@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParser_GetPackage(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"a/a.go": "package a\n\nimport \"io\"\n\nvar W io.Writer\n",
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		pkgPath   string
+		wantLocal bool
+		wantErr   bool
+	}{
+		"local package": {
+			pkgPath:   "example.com/m/a",
+			wantLocal: true,
+		},
+		"dependency": {
+			pkgPath: "io",
+		},
+		"standard library package loaded on request": {
+			pkgPath: "net/url",
+		},
+		"unknown package": {
+			pkgPath: "example.com/unknown",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg, err := p.GetPackage(tt.pkgPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPackage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if pkg.PkgPath != tt.pkgPath {
+				t.Errorf("PkgPath = %s, want %s", pkg.PkgPath, tt.pkgPath)
+			}
+			if len(pkg.Syntax) == 0 {
+				t.Error("Syntax is empty")
+			}
+			if got := p.IsLocal(tt.pkgPath); got != tt.wantLocal {
+				t.Errorf("IsLocal() = %v, want %v", got, tt.wantLocal)
+			}
+		})
+	}
+}
+
+func TestParser_GetDependencyPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"a/a.go": "package a\n\nimport \"io\"\n\nvar W io.Writer\n",
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := p.GetPackage("net/url"); err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, pkg := range p.GetDependencyPackages() {
+		got[pkg.PkgPath] = true
+	}
+	for _, want := range []string{"io", "net/url"} {
+		if !got[want] {
+			t.Errorf("GetDependencyPackages() does not contain %s", want)
+		}
+	}
+	if got["example.com/m/a"] {
+		t.Error("GetDependencyPackages() contains the local package example.com/m/a")
+	}
+}
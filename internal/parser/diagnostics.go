@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Kinds of package errors
+const (
+	ErrorKindList    = "list"    // Error reported by the build system, such as a missing import
+	ErrorKindParse   = "parse"   // Syntax error
+	ErrorKindType    = "type"    // Type-checking error
+	ErrorKindUnknown = "unknown" // Error of unknown origin
+)
+
+// PackageError represents an error reported while loading a package
+type PackageError struct {
+	Kind     string // Error kind
+	Position string // Source position relative to the root directory, empty if unknown
+	Message  string // Error message
+}
+
+// PackageDiagnostics represents the errors of a package
+type PackageDiagnostics struct {
+	PkgPath      string         // Package path
+	IsDependency bool           // Whether the package is outside the root directory
+	Errors       []PackageError // Errors in the order they were reported
+}
+
+// errorPosition matches the file name, line and column of a package error position.
+var errorPosition = regexp.MustCompile(`^(.+?)(:\d+(?::\d+)?)$`)
+
+// GetPackageErrors returns the errors reported while loading the package with the specified path.
+// It returns nil if the package loaded cleanly or has not been loaded.
+// Documentation from a package with errors may be incomplete, since declarations that
+// failed to type-check are missing.
+func (p *Parser) GetPackageErrors(pkgPath string) []PackageError {
+	p.mu.RLock()
+	pkg, ok := p.pkgs[pkgPath]
+	if !ok {
+		pkg, ok = p.extra[pkgPath]
+	}
+	p.mu.RUnlock()
+	if !ok {
+		pkg, ok = p.dependencies()[pkgPath]
+	}
+	if !ok {
+		return nil
+	}
+	return p.packageErrors(pkg)
+}
+
+// GetDiagnostics returns the errors of the packages under the root directory that failed to load,
+// sorted by package path. The errors of their dependencies are included if includeDeps is set.
+func (p *Parser) GetDiagnostics(includeDeps bool) []PackageDiagnostics {
+	diagnostics := make([]PackageDiagnostics, 0)
+	add := func(pkg *packages.Package, isDependency bool) {
+		if errs := p.packageErrors(pkg); len(errs) > 0 {
+			diagnostics = append(diagnostics, PackageDiagnostics{PkgPath: pkg.PkgPath, IsDependency: isDependency, Errors: errs})
+		}
+	}
+	for _, pkg := range p.ListPackages() {
+		add(pkg, false)
+	}
+	if includeDeps {
+		for _, pkg := range p.GetDependencyPackages() {
+			add(pkg, true)
+		}
+	}
+
+	sort.Slice(diagnostics, func(i, j int) bool {
+		return diagnostics[i].PkgPath < diagnostics[j].PkgPath
+	})
+	return diagnostics
+}
+
+// packageErrors converts the errors of pkg, with positions relative to the root directory.
+func (p *Parser) packageErrors(pkg *packages.Package) []PackageError {
+	if len(pkg.Errors) == 0 {
+		return nil
+	}
+	errs := make([]PackageError, 0, len(pkg.Errors))
+	for _, e := range pkg.Errors {
+		position := e.Pos
+		if m := errorPosition.FindStringSubmatch(position); m != nil {
+			position = p.relPath(m[1]) + m[2]
+		} else if position == "-" {
+			position = ""
+		}
+		errs = append(errs, PackageError{
+			Kind:     errorKind(e.Kind),
+			Position: position,
+			Message:  e.Msg,
+		})
+	}
+	return errs
+}
+
+// errorKind returns the name of a package error kind.
+func errorKind(kind packages.ErrorKind) string {
+	switch kind {
+	case packages.ListError:
+		return ErrorKindList
+	case packages.ParseError:
+		return ErrorKindParse
+	case packages.TypeError:
+		return ErrorKindType
+	}
+	return ErrorKindUnknown
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"ok/ok.go": `package ok
+
+// Fine compiles.
+func Fine() {}
+`,
+		"broken/broken.go": `package broken
+
+// Config is configuration.
+type Config struct {
+	Timeout Duration
+}
+
+// Load loads the configuration.
+func Load() int { return "config" }
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	want := []PackageDiagnostics{
+		{
+			PkgPath: "example.com/m/broken",
+			Errors: []PackageError{
+				{Kind: ErrorKindType, Position: "broken/broken.go:5:10", Message: "undefined: Duration"},
+				{Kind: ErrorKindType, Position: "broken/broken.go:9:26", Message: `cannot use "config" (untyped string constant) as int value in return statement`},
+			},
+		},
+	}
+	if got := p.GetDiagnostics(false); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetDiagnostics() = %+v, want %+v", got, want)
+	}
+
+	if got := p.GetPackageErrors("example.com/m/ok"); got != nil {
+		t.Errorf("GetPackageErrors() of a clean package = %+v, want nil", got)
+	}
+	if got := p.GetPackageErrors("example.com/m/broken"); len(got) != 2 {
+		t.Errorf("GetPackageErrors() = %+v, want 2 errors", got)
+	}
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"github.com/budougumi0617/godoc-mcp/internal/fulltext"
+)
+
+// SymbolKindPackage is the kind of package documentation returned by SearchDocs
+const SymbolKindPackage = "package"
+
+// DocMatch represents a documented symbol matching a full-text query
+type DocMatch struct {
+	Name     string  // Symbol name, qualified with the type name for methods and fields
+	Kind     string  // Symbol kind
+	PkgPath  string  // Package path of the symbol
+	Snippet  string  // Sentence of the comment that best matches the query
+	Position string  // Source position of the declaration
+	Score    float64 // Relevance score, higher is better
+}
+
+// docIndex is a full-text index over the doc comments of the loaded packages.
+type docIndex struct {
+	generation uint64 // Package map generation the index was built from
+	index      *fulltext.Index
+	entries    []docEntry // Indexed symbols by document ID
+}
+
+// docEntry represents an indexed doc comment.
+type docEntry struct {
+	name     string
+	kind     string
+	pkgPath  string
+	comment  string
+	position string
+}
+
+// SearchDocs searches the doc comments of the loaded packages for a natural-language query.
+// Comments are tokenized and stemmed, and the matches are ranked with BM25.
+// At most limit matches are returned if limit is positive.
+func (p *Parser) SearchDocs(query string, limit int) []DocMatch {
+	idx := p.docIndex()
+
+	matches := make([]DocMatch, 0)
+	for _, r := range idx.index.Search(query, limit) {
+		e := idx.entries[r.ID]
+		matches = append(matches, DocMatch{
+			Name:     e.name,
+			Kind:     e.kind,
+			PkgPath:  e.pkgPath,
+			Snippet:  fulltext.Snippet(e.comment, query),
+			Position: e.position,
+			Score:    r.Score,
+		})
+	}
+	return matches
+}
+
+// docIndex returns the doc comment index for the current packages, building it if needed.
+func (p *Parser) docIndex() *docIndex {
+	p.mu.RLock()
+	idx, generation := p.docs, p.generation
+	p.mu.RUnlock()
+	if idx != nil && idx.generation == generation {
+		return idx
+	}
+
+	idx = p.buildDocIndex(generation)
+
+	p.mu.Lock()
+	// Keep the index only if no reload happened while building it
+	if p.generation == generation {
+		p.docs = idx
+	}
+	p.mu.Unlock()
+
+	return idx
+}
+
+// buildDocIndex indexes the package comments and the symbol comments of the loaded packages,
+// and in lazy mode, of the packages in the index cache.
+func (p *Parser) buildDocIndex(generation uint64) *docIndex {
+	idx := &docIndex{
+		generation: generation,
+		index:      fulltext.NewIndex(),
+	}
+	add := func(e docEntry) {
+		if e.comment == "" {
+			return
+		}
+		// Names are indexed too, so that a query can match words of an identifier
+		idx.index.Add(e.name + " " + e.comment)
+		idx.entries = append(idx.entries, e)
+	}
+
+	for _, pkgIdx := range p.packageIndexes() {
+		add(docEntry{
+			name:    pkgIdx.Name,
+			kind:    SymbolKindPackage,
+			pkgPath: pkgIdx.PkgPath,
+			comment: pkgIdx.Comment,
+		})
+		for i := range pkgIdx.Symbols {
+			s := &pkgIdx.Symbols[i]
+			add(docEntry{
+				name:     s.Name,
+				kind:     s.Kind,
+				pkgPath:  pkgIdx.PkgPath,
+				comment:  s.Comment,
+				position: p.indexPosition(s),
+			})
+		}
+	}
+
+	return idx
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParser_SearchDocs(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"client/client.go": `// Package client talks to remote servers.
+package client
+
+// Do sends a request to the server.
+func Do() {}
+
+// Retry calls fn until it succeeds, waiting with exponential backoff between attempts.
+func Retry(fn func() error) {}
+
+func undocumented() {}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	matches := p.SearchDocs("retrying with backoff", 10)
+	if len(matches) != 1 {
+		t.Fatalf("len(SearchDocs()) = %d, want 1: %+v", len(matches), matches)
+	}
+	want := DocMatch{
+		Name:     "Retry",
+		Kind:     SymbolKindFunc,
+		PkgPath:  "example.com/m/client",
+		Snippet:  "Retry calls fn until it succeeds, waiting with exponential backoff between attempts.",
+		Position: "client/client.go:8",
+		Score:    matches[0].Score,
+	}
+	if matches[0] != want {
+		t.Errorf("SearchDocs() = %+v, want %+v", matches[0], want)
+	}
+
+	if matches := p.SearchDocs("remote servers", 10); len(matches) == 0 || matches[0].Kind != SymbolKindPackage {
+		t.Errorf("SearchDocs() = %+v, want package client first", matches)
+	}
+
+	// The index is rebuilt after a reload
+	writeFiles(t, dir, map[string]string{
+		"client/cache.go": "package client\n\n// Evict removes stale entries from the cache.\nfunc Evict() {}\n",
+	})
+	if err := p.Reload([]string{filepath.Join(dir, "client/cache.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if matches := p.SearchDocs("stale cache entries", 10); len(matches) == 0 || matches[0].Name != "Evict" {
+		t.Errorf("SearchDocs() = %+v, want Evict first", matches)
+	}
+}
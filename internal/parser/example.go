@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// exampleIndex maps documented identifiers to their examples.
+// Functions and types are keyed by name, methods by "Type.Method", and package examples by "".
+type exampleIndex map[string][]Example
+
+// outputPrefix matches the comment introducing the expected output of an example.
+var outputPrefix = regexp.MustCompile(`(?i)^[[:space:]]*(unordered )?output:`)
+
+// GetExamples returns the examples of the named identifier in pkg.
+// Functions and types are named by their name, methods by "Type.Method", and the package by "".
+func (p *Parser) GetExamples(pkg *packages.Package, name string) []Example {
+	p.mu.RLock()
+	index, ok := p.examples[pkg]
+	p.mu.RUnlock()
+	if !ok {
+		index = buildExampleIndex(pkg)
+		p.mu.Lock()
+		p.examples[pkg] = index
+		p.mu.Unlock()
+	}
+
+	examples := index[name]
+	if examples == nil {
+		return make([]Example, 0)
+	}
+	return examples
+}
+
+// buildExampleIndex parses the test files of pkg and associates their examples with the
+// identifiers they document, following the go/doc naming conventions: Example, Example_suffix,
+// ExampleF, ExampleT, ExampleT_M, each optionally followed by a lowercase suffix.
+// Test files are only parsed, not type-checked.
+func buildExampleIndex(pkg *packages.Package) exampleIndex {
+	index := make(exampleIndex)
+	dir := packageDir(pkg)
+	if dir == "" {
+		return index
+	}
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return index
+	}
+
+	files := append([]*ast.File{}, pkg.Syntax...)
+	for _, name := range append(bp.TestGoFiles, bp.XTestGoFiles...) {
+		file, err := parser.ParseFile(pkg.Fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		files = append(files, file)
+	}
+	if len(files) == len(pkg.Syntax) {
+		return index
+	}
+
+	dpkg, err := doc.NewFromFiles(pkg.Fset, files, pkg.PkgPath, doc.AllDecls|doc.PreserveAST)
+	if err != nil {
+		return index
+	}
+	add := func(key string, examples []*doc.Example) {
+		for _, ex := range examples {
+			index[key] = append(index[key], toExample(pkg, ex))
+		}
+	}
+	add("", dpkg.Examples)
+	for _, fn := range dpkg.Funcs {
+		add(fn.Name, fn.Examples)
+	}
+	for _, t := range dpkg.Types {
+		add(t.Name, t.Examples)
+		for _, fn := range t.Funcs {
+			add(fn.Name, fn.Examples)
+		}
+		for _, m := range t.Methods {
+			add(t.Name+"."+m.Name, m.Examples)
+		}
+	}
+	return index
+}
+
+// toExample converts an example found by go/doc.
+// The code of a whole-file example is the runnable program built from the file; otherwise
+// it is the body of the example function. The output comment is dropped from both.
+func toExample(pkg *packages.Package, ex *doc.Example) Example {
+	example := Example{
+		Name:      "Example" + ex.Name,
+		Output:    strings.TrimSpace(ex.Output),
+		Unordered: ex.Unordered,
+	}
+	if _, ok := ex.Code.(*ast.File); ok && ex.Play != nil {
+		var sb strings.Builder
+		if err := format.Node(&sb, pkg.Fset, ex.Play); err == nil {
+			example.Code = sb.String()
+		}
+		return example
+	}
+
+	// Drop the output comment, which is reported separately
+	comments := make([]*ast.CommentGroup, 0, len(ex.Comments))
+	for _, c := range ex.Comments {
+		if c.Pos() >= ex.Code.Pos() && c.End() <= ex.Code.End() && outputPrefix.MatchString(c.Text()) {
+			continue
+		}
+		comments = append(comments, c)
+	}
+
+	var sb strings.Builder
+	if err := format.Node(&sb, pkg.Fset, &printer.CommentedNode{Node: ex.Code, Comments: comments}); err != nil {
+		return example
+	}
+	code := sb.String()
+	if _, ok := ex.Code.(*ast.BlockStmt); ok {
+		code = unindentBlock(code)
+	}
+	example.Code = code
+	return example
+}
+
+// unindentBlock strips the braces of a formatted block statement and unindents its contents.
+func unindentBlock(block string) string {
+	block = strings.TrimSpace(block)
+	block = strings.TrimPrefix(block, "{")
+	block = strings.TrimSuffix(block, "}")
+	lines := strings.Split(strings.Trim(block, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetExamples(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"greet/greet.go": `package greet
+
+import "fmt"
+
+// Hello greets name.
+func Hello(name string) string { return fmt.Sprintf("Hello, %s!", name) }
+
+// HelloAll greets all names.
+func HelloAll(names ...string) []string {
+	greetings := make([]string, 0, len(names))
+	for _, name := range names {
+		greetings = append(greetings, Hello(name))
+	}
+	return greetings
+}
+
+// Greeter greets people.
+type Greeter struct {
+	Greeting string
+}
+
+// Greet greets name.
+func (g Greeter) Greet(name string) string { return g.Greeting + ", " + name }
+`,
+		"greet/greet_test.go": `package greet
+
+import "fmt"
+
+func ExampleHello() {
+	// Greet a friend
+	fmt.Println(Hello("Gopher"))
+	// Output: Hello, Gopher!
+}
+
+func ExampleHello_twice() {
+	fmt.Println(Hello("a"))
+	fmt.Println(Hello("b"))
+	// Output:
+	// Hello, a!
+	// Hello, b!
+}
+
+func ExampleHelloAll() {
+	for _, g := range HelloAll("a", "b") {
+		fmt.Println(g)
+	}
+	// Unordered output:
+	// Hello, b!
+	// Hello, a!
+}
+
+func ExampleGreeter_Greet() {
+	g := Greeter{Greeting: "Hi"}
+	fmt.Println(g.Greet("Gopher"))
+	// Output: Hi, Gopher
+}
+
+// Not an example: the suffix does not start with a lowercase letter.
+func ExampleHello_Bad() {}
+`,
+		"greet/example_test.go": `package greet_test
+
+import (
+	"fmt"
+
+	"example.com/m/greet"
+)
+
+func Example() {
+	fmt.Println(greet.Hello("world"))
+}
+
+func ExampleGreeter() {
+	g := greet.Greeter{Greeting: "Hey"}
+	fmt.Println(g.Greet("you"))
+	// Output: Hey, you
+}
+`,
+		"whole/whole.go": `package whole
+
+// Twice returns 2*x.
+func Twice(x int) int { return 2 * x }
+`,
+		"whole/example_test.go": `package whole_test
+
+import (
+	"fmt"
+
+	"example.com/m/whole"
+)
+
+func four() int { return whole.Twice(2) }
+
+func ExampleTwice() {
+	fmt.Println(four())
+	// Output: 4
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		pkgPath string
+		name    string
+		want    []Example
+	}{
+		"function with output and suffixed example": {
+			pkgPath: "example.com/m/greet",
+			name:    "Hello",
+			want: []Example{
+				{Name: "ExampleHello", Code: "// Greet a friend\nfmt.Println(Hello(\"Gopher\"))", Output: "Hello, Gopher!"},
+				{Name: "ExampleHello_twice", Code: "fmt.Println(Hello(\"a\"))\nfmt.Println(Hello(\"b\"))", Output: "Hello, a!\nHello, b!"},
+			},
+		},
+		"function sharing a prefix with another function": {
+			pkgPath: "example.com/m/greet",
+			name:    "HelloAll",
+			want: []Example{
+				{Name: "ExampleHelloAll", Code: "for _, g := range HelloAll(\"a\", \"b\") {\n\tfmt.Println(g)\n}", Output: "Hello, b!\nHello, a!", Unordered: true},
+			},
+		},
+		"method": {
+			pkgPath: "example.com/m/greet",
+			name:    "Greeter.Greet",
+			want: []Example{
+				{Name: "ExampleGreeter_Greet", Code: "g := Greeter{Greeting: \"Hi\"}\nfmt.Println(g.Greet(\"Gopher\"))", Output: "Hi, Gopher"},
+			},
+		},
+		"type in an external test package": {
+			pkgPath: "example.com/m/greet",
+			name:    "Greeter",
+			want: []Example{
+				{Name: "ExampleGreeter", Code: "g := greet.Greeter{Greeting: \"Hey\"}\nfmt.Println(g.Greet(\"you\"))", Output: "Hey, you"},
+			},
+		},
+		"package": {
+			pkgPath: "example.com/m/greet",
+			name:    "",
+			want: []Example{
+				{Name: "Example", Code: "fmt.Println(greet.Hello(\"world\"))"},
+			},
+		},
+		"whole file example": {
+			pkgPath: "example.com/m/whole",
+			name:    "Twice",
+			want: []Example{
+				{
+					Name:   "ExampleTwice",
+					Code:   "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/m/whole\"\n)\n\nfunc four() int { return whole.Twice(2) }\n\nfunc main() {\n\tfmt.Println(four())\n}\n",
+					Output: "4",
+				},
+			},
+		},
+		"no examples": {
+			pkgPath: "example.com/m/whole",
+			name:    "Missing",
+			want:    []Example{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg, err := p.GetPackage(tt.pkgPath)
+			if err != nil {
+				t.Fatalf("GetPackage() error = %v", err)
+			}
+			got := p.GetExamples(pkg, tt.name)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetExamples() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_GetExamples_AfterReload(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"a/a.go":      "package a\n\nfunc A() {}\n",
+		"a/a_test.go": "package a\n\nfunc ExampleA() {\n\tA()\n}\n",
+		"b/b.go":      "package b\n",
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	a, err := p.GetPackage("example.com/m/a")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	if got := p.GetExamples(a, "A"); len(got) != 1 {
+		t.Fatalf("GetExamples() = %v, want ExampleA", got)
+	}
+
+	// Examples of the packages that were not reloaded are kept, so their test files are not parsed again
+	writeFiles(t, dir, map[string]string{
+		"b/b.go": "package b\n\nfunc B() {}\n",
+	})
+	if err := p.Reload([]string{filepath.Join(dir, "b", "b.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	p.mu.RLock()
+	_, ok := p.examples[a]
+	p.mu.RUnlock()
+	if !ok {
+		t.Errorf("examples of example.com/m/a were discarded by reloading example.com/m/b")
+	}
+}
@@ -0,0 +1,285 @@
+package parser
+
+import (
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// TypeParam represents a type parameter of a generic function or type
+type TypeParam struct {
+	Name       string   // Type parameter name
+	Constraint string   // Constraint of the type parameter
+	TypeSet    []string // Types permitted by the constraint, nil if any type with the required methods is permitted
+}
+
+// Instantiation represents the use of a generic function or type with concrete type arguments
+type Instantiation struct {
+	TypeArgs []string // Type arguments
+	Type     string   // Instantiated type or signature
+	Position string   // Source position of the first use
+	Count    int      // Number of uses in the loaded packages
+}
+
+// getTypeParams returns information about the type parameters in list.
+// Types are qualified with qf.
+func getTypeParams(list *types.TypeParamList, qf types.Qualifier) []TypeParam {
+	if list.Len() == 0 {
+		return nil
+	}
+	params := make([]TypeParam, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		tp := list.At(i)
+		params = append(params, TypeParam{
+			Name:       tp.Obj().Name(),
+			Constraint: types.TypeString(tp.Constraint(), qf),
+			TypeSet:    constraintTypeSet(tp.Constraint(), qf),
+		})
+	}
+	return params
+}
+
+// constraintTypeSet returns the types permitted by a constraint as readable terms,
+// with embedded constraint interfaces expanded. It returns nil if the constraint
+// does not restrict the permitted types, and ["comparable"] if it only requires
+// comparable types. Types are qualified with qf.
+func constraintTypeSet(constraint types.Type, qf types.Qualifier) []string {
+	terms, restricted := typeSetTerms(constraint, qf)
+	if !restricted {
+		if iface, ok := constraint.Underlying().(*types.Interface); ok && iface.IsComparable() {
+			return []string{"comparable"}
+		}
+		return nil
+	}
+	if terms == nil {
+		terms = []string{}
+	}
+	return terms
+}
+
+// typeSetTerms returns the terms of the type set of t and whether the type set is restricted to them.
+// Multiple embedded elements are intersected by their terms.
+func typeSetTerms(t types.Type, qf types.Qualifier) ([]string, bool) {
+	iface, ok := t.Underlying().(*types.Interface)
+	if !ok {
+		return []string{types.TypeString(t, qf)}, true
+	}
+
+	var terms []string
+	restricted := false
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		var elemTerms []string
+		elemRestricted := true
+
+		switch embedded := iface.EmbeddedType(i).(type) {
+		case *types.Union:
+			for j := 0; j < embedded.Len(); j++ {
+				term := embedded.Term(j)
+				if term.Tilde() {
+					elemTerms = append(elemTerms, "~"+types.TypeString(term.Type(), qf))
+					continue
+				}
+				subTerms, subRestricted := typeSetTerms(term.Type(), qf)
+				if !subRestricted {
+					elemRestricted = false
+					break
+				}
+				elemTerms = append(elemTerms, subTerms...)
+			}
+		default:
+			elemTerms, elemRestricted = typeSetTerms(embedded, qf)
+		}
+
+		if !elemRestricted {
+			continue
+		}
+		if !restricted {
+			terms, restricted = dedupe(elemTerms), true
+			continue
+		}
+		terms = intersect(terms, elemTerms)
+	}
+	return terms, restricted
+}
+
+// dedupe returns list without duplicates, preserving the order.
+func dedupe(list []string) []string {
+	seen := make(map[string]bool, len(list))
+	result := make([]string, 0, len(list))
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// intersect returns the elements of a that are also in b.
+func intersect(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	result := make([]string, 0, len(a))
+	for _, s := range a {
+		if inB[s] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// getInstantiations returns the instantiations of a generic package-level function or type
+// in the loaded packages, most used first. Types are qualified relative to the package of obj.
+func (p *Parser) getInstantiations(obj types.Object) []Instantiation {
+	qf := qualifier(obj.Pkg())
+	byArgs := make(map[string]*Instantiation)
+	first := make(map[string]token.Position) // Position of the first use of each instantiation
+	for _, pkg := range p.GetAllPackages() {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		for ident, inst := range pkg.TypesInfo.Instances {
+			if !samePackageObject(pkg.TypesInfo.Uses[ident], obj) {
+				continue
+			}
+
+			args := make([]string, 0, inst.TypeArgs.Len())
+			concrete := true
+			for i := 0; i < inst.TypeArgs.Len(); i++ {
+				arg := inst.TypeArgs.At(i)
+				if hasTypeParam(arg) {
+					// Uses inside generic code, such as receivers of methods, are not instantiations
+					concrete = false
+					break
+				}
+				args = append(args, types.TypeString(arg, qf))
+			}
+			if !concrete {
+				continue
+			}
+
+			key := strings.Join(args, ", ")
+			position := pkg.Fset.Position(ident.Pos())
+			if existing, ok := byArgs[key]; ok {
+				existing.Count++
+				if before(position, first[key]) {
+					existing.Position = p.position(pkg.Fset, ident.Pos())
+					first[key] = position
+				}
+				continue
+			}
+			byArgs[key] = &Instantiation{
+				TypeArgs: args,
+				Type:     types.TypeString(inst.Type, qf),
+				Position: p.position(pkg.Fset, ident.Pos()),
+				Count:    1,
+			}
+			first[key] = position
+		}
+	}
+
+	instantiations := make([]Instantiation, 0, len(byArgs))
+	for _, inst := range byArgs {
+		instantiations = append(instantiations, *inst)
+	}
+	sort.Slice(instantiations, func(i, j int) bool {
+		if instantiations[i].Count != instantiations[j].Count {
+			return instantiations[i].Count > instantiations[j].Count
+		}
+		return strings.Join(instantiations[i].TypeArgs, ", ") < strings.Join(instantiations[j].TypeArgs, ", ")
+	})
+	return instantiations
+}
+
+// before reports whether a comes before b, comparing file names, then lines and columns.
+func before(a, b token.Position) bool {
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
+
+// samePackageObject reports whether a and b denote the same package-level object.
+// Objects are compared by package path and name, so that objects of packages
+// type-checked separately, such as after a reload, are considered the same.
+func samePackageObject(a, b types.Object) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	return a.Pkg() != nil && b.Pkg() != nil && a.Pkg().Path() == b.Pkg().Path() && a.Name() == b.Name()
+}
+
+// hasTypeParam reports whether t refers to a type parameter.
+// Named types and aliases are only inspected through their type arguments, which also stops
+// the recursion at recursive types.
+func hasTypeParam(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return hasTypeParam(t.Elem())
+	case *types.Slice:
+		return hasTypeParam(t.Elem())
+	case *types.Array:
+		return hasTypeParam(t.Elem())
+	case *types.Chan:
+		return hasTypeParam(t.Elem())
+	case *types.Map:
+		return hasTypeParam(t.Key()) || hasTypeParam(t.Elem())
+	case *types.Named:
+		return typeListHasTypeParam(t.TypeArgs())
+	case *types.Alias:
+		return typeListHasTypeParam(t.TypeArgs())
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if hasTypeParam(t.At(i).Type()) {
+				return true
+			}
+		}
+	case *types.Signature:
+		return hasTypeParam(t.Params()) || hasTypeParam(t.Results())
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if hasTypeParam(t.Field(i).Type()) {
+				return true
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumExplicitMethods(); i++ {
+			if hasTypeParam(t.ExplicitMethod(i).Type()) {
+				return true
+			}
+		}
+		for i := 0; i < t.NumEmbeddeds(); i++ {
+			if hasTypeParam(t.EmbeddedType(i)) {
+				return true
+			}
+		}
+	case *types.Union:
+		for i := 0; i < t.Len(); i++ {
+			if hasTypeParam(t.Term(i).Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeListHasTypeParam reports whether a type of list refers to a type parameter.
+func typeListHasTypeParam(list *types.TypeList) bool {
+	for i := 0; i < list.Len(); i++ {
+		if hasTypeParam(list.At(i)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_Generics(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"gen/gen.go": `package gen
+
+type (
+	Integer interface{ ~int | ~int64 }
+	Float   interface{ ~float32 | ~float64 }
+	Number  interface{ Integer | Float }
+)
+
+func Sum[T Number](xs []T) T {
+	var t T
+	return t
+}
+
+type Set[K comparable, V any] struct {
+	items map[K]V
+}
+
+func (s *Set[K, V]) Add(k K, v V) {}
+`,
+		"use/use.go": `package use
+
+import "example.com/m/gen"
+
+var (
+	a = gen.Sum([]int{1})
+	b = gen.Sum([]int{2})
+	c = gen.Sum([]float64{3})
+	s gen.Set[string, int]
+)
+
+var t gen.Set[string, int]
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	t.Run("function", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetFuncInfo("example.com/m/gen", "Sum")
+		if err != nil {
+			t.Fatalf("GetFuncInfo() error = %v", err)
+		}
+		if want := "func Sum[T Number](xs []T) T"; info.Signature != want {
+			t.Errorf("Signature = %q, want %q", info.Signature, want)
+		}
+		wantParams := []TypeParam{
+			{Name: "T", Constraint: "Number", TypeSet: []string{"~int", "~int64", "~float32", "~float64"}},
+		}
+		if !reflect.DeepEqual(info.TypeParams, wantParams) {
+			t.Errorf("TypeParams = %+v, want %+v", info.TypeParams, wantParams)
+		}
+		wantInst := []Instantiation{
+			{TypeArgs: []string{"int"}, Type: "func(xs []int) int", Position: "use/use.go:6", Count: 2},
+			{TypeArgs: []string{"float64"}, Type: "func(xs []float64) float64", Position: "use/use.go:8", Count: 1},
+		}
+		if !reflect.DeepEqual(info.Instantiations, wantInst) {
+			t.Errorf("Instantiations = %+v, want %+v", info.Instantiations, wantInst)
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetStructInfo("example.com/m/gen", "Set")
+		if err != nil {
+			t.Fatalf("GetStructInfo() error = %v", err)
+		}
+		wantParams := []TypeParam{
+			{Name: "K", Constraint: "comparable", TypeSet: []string{"comparable"}},
+			{Name: "V", Constraint: "any"},
+		}
+		if !reflect.DeepEqual(info.TypeParams, wantParams) {
+			t.Errorf("TypeParams = %+v, want %+v", info.TypeParams, wantParams)
+		}
+		wantInst := []Instantiation{
+			{TypeArgs: []string{"string", "int"}, Type: "Set[string, int]", Position: "use/use.go:9", Count: 2},
+		}
+		if !reflect.DeepEqual(info.Instantiations, wantInst) {
+			t.Errorf("Instantiations = %+v, want %+v", info.Instantiations, wantInst)
+		}
+	})
+
+	t.Run("method of generic type", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetMethodInfo("example.com/m/gen", "Set", "Add")
+		if err != nil {
+			t.Fatalf("GetMethodInfo() error = %v", err)
+		}
+		if len(info.TypeParams) != 2 {
+			t.Errorf("len(TypeParams) = %d, want 2", len(info.TypeParams))
+		}
+	})
+
+	t.Run("constraint interface", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetInterfaceInfo("example.com/m/gen", "Number")
+		if err != nil {
+			t.Fatalf("GetInterfaceInfo() error = %v", err)
+		}
+		if want := []string{"~int", "~int64", "~float32", "~float64"}; !reflect.DeepEqual(info.TypeSet, want) {
+			t.Errorf("TypeSet = %v, want %v", info.TypeSet, want)
+		}
+	})
+}
+
+func TestConstraintTypeSet(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"c/c.go": `package c
+
+import "fmt"
+
+type (
+	Any        interface{}
+	Stringer   interface{ fmt.Stringer }
+	Comparable interface{ comparable }
+	Signed     interface{ ~int | ~int64 }
+	Both       interface {
+		~int | ~string
+		Signed
+	}
+)
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	pkg, err := p.GetPackage("example.com/m/c")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+
+	tests := map[string][]string{
+		"Any":        nil,
+		"Stringer":   nil,
+		"Comparable": {"comparable"},
+		"Signed":     {"~int", "~int64"},
+		"Both":       {"~int"},
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := constraintTypeSet(pkg.Types.Scope().Lookup(name).Type(), qualifier(pkg.Types))
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("constraintTypeSet() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParser_GenericsInGenericCode(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"gen/gen.go": `package gen
+
+func Use[T any](v T) {}
+
+type Pair[K, V any] = struct {
+	Key   K
+	Value V
+}
+
+func Wrap[T any](v T) {
+	Use(struct{ F T }{F: v})
+	Use[interface{ Get() T }](nil)
+	Use(func() (T, error) { return v, nil })
+	Use(Pair[string, T]{})
+	Use(struct{ F int }{F: 1})
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	info, err := p.GetFuncInfo("example.com/m/gen", "Use")
+	if err != nil {
+		t.Fatalf("GetFuncInfo() error = %v", err)
+	}
+	var got [][]string
+	for _, inst := range info.Instantiations {
+		got = append(got, inst.TypeArgs)
+	}
+	// Only the type arguments without type parameters are instantiations
+	if want := [][]string{{"struct{F int}"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Instantiations = %v, want %v", got, want)
+	}
+}
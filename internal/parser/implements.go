@@ -0,0 +1,277 @@
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Implementation represents a named type on one side of an implementation relationship
+type Implementation struct {
+	Name      string // Type name
+	PkgPath   string // Package path of the type
+	Position  string // Source position of the type declaration
+	IsPointer bool   // Whether only the pointer type takes part in the relationship
+}
+
+// ImplementsInfo represents the implementation relationships of a type
+type ImplementsInfo struct {
+	Name            string           // Type name
+	PkgPath         string           // Package path of the type
+	IsInterface     bool             // Whether the type is an interface
+	Implementations []Implementation // Types that implement the interface
+	Interfaces      []Implementation // Interfaces that the type implements
+}
+
+// GetImplementsInfo returns the implementation relationships of a type in the specified package.
+// For an interface, the types implementing it are returned. For any other named type,
+// the interfaces it implements are returned. Only the loaded packages are searched
+// unless includeDeps is true, in which case their dependencies are searched as well.
+// The type may be declared in any package that GetPackage can find.
+func (p *Parser) GetImplementsInfo(pkgPath, typeName string, includeDeps bool) (*ImplementsInfo, error) {
+	pkg, err := p.GetPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type not found: %s in package %s", typeName, pkgPath)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("not a named type: %s in package %s", typeName, pkgPath)
+	}
+	if named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("generic type is not supported: %s in package %s", typeName, pkgPath)
+	}
+
+	pkgs := p.GetAllPackages()
+	if includeDeps {
+		pkgs = withDependencies(pkgs)
+	}
+
+	info := &ImplementsInfo{
+		Name:    typeName,
+		PkgPath: pkg.PkgPath,
+	}
+	if iface, ok := named.Underlying().(*types.Interface); ok {
+		info.IsInterface = true
+		info.Implementations = p.findImplementations(named, iface, pkgs)
+	} else {
+		info.Interfaces = p.findInterfaces(named, pkgs)
+	}
+
+	return info, nil
+}
+
+// findImplementations returns the named types in pkgs that implement iface.
+func (p *Parser) findImplementations(target *types.Named, iface *types.Interface, pkgs []*packages.Package) []Implementation {
+	implementations := make([]Implementation, 0)
+	for _, pkg := range pkgs {
+		p.forEachNamed(pkg, func(tn *types.TypeName, named *types.Named) {
+			if named == target || types.IsInterface(named) {
+				return
+			}
+
+			var isPointer bool
+			switch {
+			case implements(named, iface):
+			case implements(types.NewPointer(named), iface):
+				isPointer = true
+			default:
+				return
+			}
+			implementations = append(implementations, Implementation{
+				Name:      tn.Name(),
+				PkgPath:   pkg.PkgPath,
+				Position:  p.position(pkg.Fset, tn.Pos()),
+				IsPointer: isPointer,
+			})
+		})
+	}
+
+	sortImplementations(implementations)
+	return implementations
+}
+
+// findInterfaces returns the interfaces in pkgs that target implements.
+// Empty interfaces and constraint interfaces are omitted.
+func (p *Parser) findInterfaces(target *types.Named, pkgs []*packages.Package) []Implementation {
+	interfaces := make([]Implementation, 0)
+	for _, pkg := range pkgs {
+		p.forEachNamed(pkg, func(tn *types.TypeName, named *types.Named) {
+			iface, ok := named.Underlying().(*types.Interface)
+			if !ok || named == target || iface.NumMethods() == 0 || !iface.IsMethodSet() {
+				return
+			}
+
+			var isPointer bool
+			switch {
+			case implements(target, iface):
+			case implements(types.NewPointer(target), iface):
+				isPointer = true
+			default:
+				return
+			}
+			interfaces = append(interfaces, Implementation{
+				Name:      tn.Name(),
+				PkgPath:   pkg.PkgPath,
+				Position:  p.position(pkg.Fset, tn.Pos()),
+				IsPointer: isPointer,
+			})
+		})
+	}
+
+	sortImplementations(interfaces)
+	return interfaces
+}
+
+// forEachNamed calls fn for every non-generic named type declared at package level in pkg.
+// Unexported types are skipped for dependencies of the loaded packages.
+func (p *Parser) forEachNamed(pkg *packages.Package, fn func(tn *types.TypeName, named *types.Named)) {
+	if pkg.Types == nil {
+		return
+	}
+	isDependency := !p.IsLocal(pkg.PkgPath)
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		if !tn.Exported() && isDependency {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue
+		}
+		fn(tn, named)
+	}
+}
+
+// implements reports whether t implements iface, or satisfies it if iface is a constraint.
+// Packages loaded separately, such as after a reload, have distinct types for their common
+// dependencies, so the methods of iface are also matched with identicalAcross.
+func implements(t types.Type, iface *types.Interface) bool {
+	if !iface.IsMethodSet() {
+		return types.Satisfies(t, iface)
+	}
+	if types.Implements(t, iface) {
+		return true
+	}
+
+	mset := types.NewMethodSet(t)
+	for i := 0; i < iface.NumMethods(); i++ {
+		m := iface.Method(i)
+		sel := mset.Lookup(m.Pkg(), m.Name())
+		if sel == nil || !identicalAcross(sel.Obj().Type(), m.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+// identicalAcross reports whether x and y are identical, as types.Identical does, except that
+// named types are compared by package path, name and type arguments, so that types of packages
+// loaded separately match. Receivers of signatures are ignored.
+func identicalAcross(x, y types.Type) bool {
+	x, y = types.Unalias(x), types.Unalias(y)
+	if x == y {
+		return true
+	}
+	switch x := x.(type) {
+	case *types.Basic:
+		y, ok := y.(*types.Basic)
+		return ok && x.Kind() == y.Kind()
+	case *types.Pointer:
+		y, ok := y.(*types.Pointer)
+		return ok && identicalAcross(x.Elem(), y.Elem())
+	case *types.Slice:
+		y, ok := y.(*types.Slice)
+		return ok && identicalAcross(x.Elem(), y.Elem())
+	case *types.Array:
+		y, ok := y.(*types.Array)
+		return ok && x.Len() == y.Len() && identicalAcross(x.Elem(), y.Elem())
+	case *types.Map:
+		y, ok := y.(*types.Map)
+		return ok && identicalAcross(x.Key(), y.Key()) && identicalAcross(x.Elem(), y.Elem())
+	case *types.Chan:
+		y, ok := y.(*types.Chan)
+		return ok && x.Dir() == y.Dir() && identicalAcross(x.Elem(), y.Elem())
+	case *types.Signature:
+		y, ok := y.(*types.Signature)
+		return ok && x.Variadic() == y.Variadic() && x.TypeParams().Len() == 0 && y.TypeParams().Len() == 0 &&
+			identicalAcross(x.Params(), y.Params()) && identicalAcross(x.Results(), y.Results())
+	case *types.Tuple:
+		y, ok := y.(*types.Tuple)
+		if !ok || x.Len() != y.Len() {
+			return false
+		}
+		for i := 0; i < x.Len(); i++ {
+			if !identicalAcross(x.At(i).Type(), y.At(i).Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Struct:
+		y, ok := y.(*types.Struct)
+		if !ok || x.NumFields() != y.NumFields() {
+			return false
+		}
+		for i := 0; i < x.NumFields(); i++ {
+			a, b := x.Field(i), y.Field(i)
+			if !sameName(a, b) || a.Embedded() != b.Embedded() || x.Tag(i) != y.Tag(i) || !identicalAcross(a.Type(), b.Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Interface:
+		y, ok := y.(*types.Interface)
+		if !ok || !x.IsMethodSet() || !y.IsMethodSet() || x.NumMethods() != y.NumMethods() {
+			return false
+		}
+		for i := 0; i < x.NumMethods(); i++ {
+			a, b := x.Method(i), y.Method(i)
+			if !sameName(a, b) || !identicalAcross(a.Type(), b.Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Named:
+		y, ok := y.(*types.Named)
+		if !ok || !samePackageObject(x.Obj(), y.Obj()) || x.TypeArgs().Len() != y.TypeArgs().Len() {
+			return false
+		}
+		for i := 0; i < x.TypeArgs().Len(); i++ {
+			if !identicalAcross(x.TypeArgs().At(i), y.TypeArgs().At(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// sameName reports whether the fields or methods a and b have the same name, and for unexported
+// names, are declared in packages with the same path.
+func sameName(a, b types.Object) bool {
+	if a.Name() != b.Name() {
+		return false
+	}
+	return a.Exported() || a.Pkg() != nil && b.Pkg() != nil && a.Pkg().Path() == b.Pkg().Path()
+}
+
+// sortImplementations sorts implementations by package path and name.
+func sortImplementations(implementations []Implementation) {
+	sort.Slice(implementations, func(i, j int) bool {
+		if implementations[i].PkgPath != implementations[j].PkgPath {
+			return implementations[i].PkgPath < implementations[j].PkgPath
+		}
+		return implementations[i].Name < implementations[j].Name
+	})
+}
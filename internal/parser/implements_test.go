@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParser_GetImplementsInfo(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"buf/buf.go": `package buf
+
+import (
+	"fmt"
+	"io"
+)
+
+var (
+	_ io.Writer    = (*Buffer)(nil)
+	_ fmt.Stringer = Buffer{}
+)
+
+type Buffer struct{}
+
+func (*Buffer) Write(p []byte) (int, error) { return len(p), nil }
+
+func (Buffer) String() string { return "" }
+
+type Named interface {
+	String() string
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		pkgPath             string
+		typeName            string
+		includeDeps         bool
+		wantInterface       bool
+		wantImplementations []string
+		wantInterfaces      []string
+	}{
+		"interface in a dependency": {
+			pkgPath:             "io",
+			typeName:            "Writer",
+			wantInterface:       true,
+			wantImplementations: []string{"*example.com/m/buf.Buffer"},
+		},
+		"local interfaces only": {
+			pkgPath:        "example.com/m/buf",
+			typeName:       "Buffer",
+			wantInterfaces: []string{"example.com/m/buf.Named"},
+		},
+		"including dependencies": {
+			pkgPath:        "example.com/m/buf",
+			typeName:       "Buffer",
+			includeDeps:    true,
+			wantInterfaces: []string{"example.com/m/buf.Named", "fmt.Stringer", "*io.Writer"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := p.GetImplementsInfo(tt.pkgPath, tt.typeName, tt.includeDeps)
+			if err != nil {
+				t.Fatalf("GetImplementsInfo() error = %v", err)
+			}
+			if info.IsInterface != tt.wantInterface {
+				t.Errorf("IsInterface = %v, want %v", info.IsInterface, tt.wantInterface)
+			}
+			if got := implementationNames(info.Implementations); !reflect.DeepEqual(got, tt.wantImplementations) {
+				t.Errorf("Implementations = %v, want %v", got, tt.wantImplementations)
+			}
+			got := implementationNames(info.Interfaces)
+			for _, want := range tt.wantInterfaces {
+				if !slices.Contains(got, want) {
+					t.Errorf("Interfaces = %v, want to contain %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+// implementationNames returns implementations as "pkgpath.Name", prefixed with "*"
+// if only the pointer type takes part in the relationship.
+func implementationNames(implementations []Implementation) []string {
+	var names []string
+	for _, impl := range implementations {
+		name := impl.PkgPath + "." + impl.Name
+		if impl.IsPointer {
+			name = "*" + name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestParser_GetImplementsInfo_AfterReload(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"api/api.go":   "package api\n\nimport \"context\"\n\ntype Handler interface {\n\tHandle(ctx context.Context) error\n}\n",
+		"impl/impl.go": "package impl\n\nimport \"context\"\n\ntype Impl struct{}\n\nfunc (Impl) Handle(ctx context.Context) error { return nil }\n",
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// Reloading one package must not break matching signatures using types of shared dependencies
+	writeFiles(t, dir, map[string]string{
+		"impl/impl.go": "package impl\n\nimport \"context\"\n\n// Impl handles requests.\ntype Impl struct{}\n\nfunc (Impl) Handle(ctx context.Context) error { return nil }\n",
+	})
+	if err := p.Reload([]string{filepath.Join(dir, "impl", "impl.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	info, err := p.GetImplementsInfo("example.com/m/api", "Handler", false)
+	if err != nil {
+		t.Fatalf("GetImplementsInfo() error = %v", err)
+	}
+	var got []string
+	for _, impl := range info.Implementations {
+		got = append(got, impl.PkgPath+"."+impl.Name)
+	}
+	if want := []string{"example.com/m/impl.Impl"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Implementations after Reload() = %v, want %v", got, want)
+	}
+
+	info, err = p.GetImplementsInfo("example.com/m/impl", "Impl", false)
+	if err != nil {
+		t.Fatalf("GetImplementsInfo() error = %v", err)
+	}
+	got = nil
+	for _, iface := range info.Interfaces {
+		got = append(got, iface.PkgPath+"."+iface.Name)
+	}
+	if want := []string{"example.com/m/api.Handler"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Interfaces after Reload() = %v, want %v", got, want)
+	}
+}
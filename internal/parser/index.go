@@ -0,0 +1,406 @@
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"log"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/budougumi0617/godoc-mcp/internal/diskcache"
+	"golang.org/x/tools/go/packages"
+)
+
+// indexVersion identifies the format of packageIndex, and is part of its cache keys.
+const indexVersion = "1"
+
+// packageIndex is the documentation of a package extracted for search: its symbols with their
+// signatures, comments and positions. It is enough to search a package without type-checking it,
+// so it is what the index cache stores.
+type packageIndex struct {
+	PkgPath string        `json:"pkg_path"`
+	Name    string        `json:"name"`
+	Comment string        `json:"comment,omitempty"`
+	Symbols []indexSymbol `json:"symbols"`
+}
+
+// indexSymbol is a symbol of a packageIndex.
+type indexSymbol struct {
+	Name      string `json:"name"` // Qualified with the type name for methods and fields
+	Kind      string `json:"kind"`
+	Exported  bool   `json:"exported"`
+	Signature string `json:"signature"`
+	Comment   string `json:"comment,omitempty"`
+	File      string `json:"file,omitempty"` // Absolute path of the declaring file
+	Line      int    `json:"line,omitempty"`
+}
+
+// indexCache holds the search indexes of the listed packages read from Options.Cache in lazy mode,
+// and the inputs of their cache keys. Its maps are guarded by Parser.mu.
+type indexCache struct {
+	cached  map[string]*packageIndex // Search indexes of the listed packages from the index cache
+	env     *indexEnv                // Inputs of the index cache keys shared by every package
+	hashes  map[string]string        // Hashes of the files of the listed packages, by package path
+	keys    map[string]string        // Index cache keys of the listed packages, by package path
+	filling map[string]string        // Index cache keys of the packages being filled in the background, by package path
+	fills   sync.WaitGroup           // Background loads filling the index cache
+}
+
+// packageIndexes returns the search indexes of the packages under the root directory, sorted by package path.
+// Type-checked packages are indexed from their type information, and the packages that are only
+// listed are indexed from the index cache if it is enabled.
+func (p *Parser) packageIndexes() []*packageIndex {
+	result := make([]*packageIndex, 0)
+	for _, pkg := range p.ListPackages() {
+		if pkg.Types != nil {
+			result = append(result, p.indexOf(pkg))
+			continue
+		}
+		p.mu.RLock()
+		idx, ok := p.cache.cached[pkg.PkgPath]
+		p.mu.RUnlock()
+		if ok {
+			result = append(result, idx)
+		}
+	}
+	return result
+}
+
+// indexOf returns the search index of a type-checked package, extracting it on first use.
+func (p *Parser) indexOf(pkg *packages.Package) *packageIndex {
+	p.mu.RLock()
+	idx, ok := p.indexes[pkg]
+	p.mu.RUnlock()
+	if ok {
+		return idx
+	}
+
+	idx = p.extractIndex(pkg)
+
+	p.mu.Lock()
+	// Keep the index only if the package was not released while extracting it
+	if _, ok := p.pkgs[pkg.PkgPath]; ok {
+		p.indexes[pkg] = idx
+	}
+	p.mu.Unlock()
+
+	return idx
+}
+
+// extractIndex extracts the search index of a type-checked package.
+func (p *Parser) extractIndex(pkg *packages.Package) *packageIndex {
+	p.mu.RLock()
+	comments, ok := p.comments[pkg]
+	p.mu.RUnlock()
+	if !ok {
+		comments = buildCommentIndex(pkg)
+	}
+
+	idx := &packageIndex{
+		PkgPath: pkg.PkgPath,
+		Name:    pkg.Name,
+		Comment: GetPackageComment(pkg),
+		Symbols: make([]indexSymbol, 0),
+	}
+	forEachSymbol(pkg, func(c symbolCandidate) {
+		s := indexSymbol{
+			Name:      c.name,
+			Kind:      c.kind,
+			Exported:  c.obj.Exported(),
+			Signature: signature(c.obj),
+			Comment:   comments[origin(c.obj)],
+		}
+		if pos := c.obj.Pos(); pos.IsValid() {
+			position := pkg.Fset.Position(pos)
+			s.File, s.Line = position.Filename, position.Line
+		}
+		idx.Symbols = append(idx.Symbols, s)
+	})
+	return idx
+}
+
+// signature returns the one-line signature of obj, as go doc -short shows it.
+// Struct and interface types are shown without their fields and methods.
+func signature(obj types.Object) string {
+	qf := qualifier(obj.Pkg())
+	switch obj := obj.(type) {
+	case *types.TypeName:
+		if obj.IsAlias() {
+			return "type " + obj.Name() + " = " + types.TypeString(obj.Type(), qf)
+		}
+		switch obj.Type().Underlying().(type) {
+		case *types.Struct:
+			return "type " + obj.Name() + " struct{ ... }"
+		case *types.Interface:
+			return "type " + obj.Name() + " interface{ ... }"
+		}
+	case *types.Const:
+		if basic, ok := obj.Type().(*types.Basic); ok && basic.Info()&types.IsUntyped != 0 {
+			return "const " + obj.Name() + " = " + obj.Val().String()
+		}
+	case *types.Var:
+		if obj.IsField() {
+			return obj.Name() + " " + types.TypeString(obj.Type(), qf)
+		}
+	}
+	return typesDeclaration(obj)
+}
+
+// indexEnv holds the inputs of the index cache keys shared by every package under the root directory:
+// the Go version of the toolchain and the files selecting the versions of dependencies, which can
+// change the signatures of a package.
+type indexEnv struct {
+	goVersion string
+	files     [][]byte
+}
+
+// readIndexEnv reads the inputs of the index cache keys shared by every package under the root directory.
+func (p *Parser) readIndexEnv() (*indexEnv, error) {
+	cmd := exec.Command("go", "env", "GOVERSION", "GOWORK")
+	cmd.Dir = p.rootDir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run go env: %w", err)
+	}
+	values := strings.Split(string(out), "\n")
+	if len(values) < 2 {
+		return nil, fmt.Errorf("unexpected output of go env: %q", out)
+	}
+
+	env := &indexEnv{goVersion: values[0]}
+	names := []string{filepath.Join(p.rootDir, "go.mod"), filepath.Join(p.rootDir, "go.sum")}
+	if work := values[1]; work != "" && work != "off" {
+		names = append(names, work, work+".sum")
+	}
+	for _, name := range names {
+		// Missing files are hashed as empty
+		data, _ := os.ReadFile(name)
+		env.files = append(env.files, data)
+	}
+	return env, nil
+}
+
+// hashFiles returns a hash of the names and contents of the files of a listed package.
+// It reports false if a file cannot be read.
+func hashFiles(pkg *packages.Package) (string, bool) {
+	parts := [][]byte{[]byte(pkg.PkgPath)}
+	for _, name := range pkg.GoFiles {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return "", false
+		}
+		parts = append(parts, []byte(name), data)
+	}
+	return diskcache.Key(parts...), true
+}
+
+// indexKeys returns the cache keys of the indexes of the listed packages, derived from the index
+// format, env, the hashes of their files and the keys of the listed packages they import, whose
+// types their signatures refer to. Packages without a hash, or importing one, have no key.
+func indexKeys(listed map[string]*packages.Package, hashes map[string]string, env *indexEnv) map[string]string {
+	keys := make(map[string]string, len(listed))
+	if env == nil {
+		return keys
+	}
+
+	failed := make(map[string]bool)
+	var keyOf func(path string) (string, bool)
+	keyOf = func(path string) (string, bool) {
+		if key, ok := keys[path]; ok {
+			return key, true
+		}
+		hash, ok := hashes[path]
+		if !ok || failed[path] {
+			return "", false
+		}
+		// Import cycles are errors, and the packages in one are not keyed
+		failed[path] = true
+
+		parts := [][]byte{[]byte(indexVersion), []byte(env.goVersion)}
+		parts = append(parts, env.files...)
+		parts = append(parts, []byte(hash))
+		imports := make([]string, 0, len(listed[path].Imports))
+		for _, imp := range listed[path].Imports {
+			if _, ok := listed[imp.PkgPath]; ok {
+				imports = append(imports, imp.PkgPath)
+			}
+		}
+		sort.Strings(imports)
+		for _, imp := range imports {
+			key, ok := keyOf(imp)
+			if !ok {
+				return "", false
+			}
+			parts = append(parts, []byte(imp), []byte(key))
+		}
+
+		key := diskcache.Key(parts...)
+		delete(failed, path)
+		keys[path] = key
+		return key, true
+	}
+	for path := range listed {
+		keyOf(path)
+	}
+	return keys
+}
+
+// refreshIndexes hashes the files of the listed packages in dirs, or of every listed package if
+// dirs is nil, and of the packages that were not listed before, and updates the cache keys.
+// It returns the search indexes of the listed packages found in the index cache, and the keys
+// of the other packages.
+// The caller must hold p.update.
+func (p *Parser) refreshIndexes(listed map[string]*packages.Package, dirs map[string]bool) (map[string]*packageIndex, map[string]string) {
+	cached := make(map[string]*packageIndex, len(listed))
+	stale := make(map[string]string)
+	if p.opts.Cache == nil {
+		return cached, stale
+	}
+
+	p.mu.RLock()
+	env, prevHashes, prevKeys, prevCached := p.cache.env, p.cache.hashes, p.cache.keys, p.cache.cached
+	p.mu.RUnlock()
+	if dirs == nil || env == nil {
+		var err error
+		if env, err = p.readIndexEnv(); err != nil {
+			log.Printf("Failed to read the index cache keys: %v", err)
+		}
+	}
+
+	hashes := make(map[string]string, len(listed))
+	for path, pkg := range listed {
+		hash, ok := prevHashes[path]
+		if !ok || dirs == nil || dirs[packageDir(pkg)] {
+			hash, ok = hashFiles(pkg)
+		}
+		if ok {
+			hashes[path] = hash
+		}
+	}
+	keys := indexKeys(listed, hashes, env)
+
+	for path, key := range keys {
+		if idx, ok := prevCached[path]; ok && prevKeys[path] == key {
+			cached[path] = idx
+			continue
+		}
+		var idx packageIndex
+		if p.opts.Cache.Get(key, &idx) {
+			cached[path] = &idx
+			continue
+		}
+		stale[path] = key
+	}
+
+	p.mu.Lock()
+	p.cache.env = env
+	p.cache.hashes = hashes
+	p.cache.keys = keys
+	p.mu.Unlock()
+
+	return cached, stale
+}
+
+// fillIndexes type-checks the listed packages missing from the index cache in the background,
+// caches their indexes, and adds them to the cached indexes unless their files changed meanwhile.
+// Packages are type-checked in loads of at most Options.MaxLocalPackages packages, like the
+// packages kept type-checked. Packages that are being filled with the same key are skipped.
+// The caller must hold p.update.
+func (p *Parser) fillIndexes(listed map[string]*packages.Package, stale map[string]string) {
+	p.mu.Lock()
+	paths := make([]string, 0, len(stale))
+	for path, key := range stale {
+		if p.cache.filling[path] != key {
+			p.cache.filling[path] = key
+			paths = append(paths, path)
+		}
+	}
+	p.mu.Unlock()
+	if len(paths) == 0 {
+		return
+	}
+	sort.Strings(paths)
+
+	size := len(paths)
+	if limit := p.opts.MaxLocalPackages; limit > 0 {
+		size = limit
+	}
+	p.cache.fills.Add(1)
+	go func() {
+		defer p.cache.fills.Done()
+
+		for batch := range slices.Chunk(paths, size) {
+			pkgs, err := packages.Load(loadConfig(p.rootDir), batch...)
+			var indexes map[string]*packageIndex
+			if err != nil {
+				log.Printf("Failed to index packages: %v", err)
+			} else {
+				indexes = p.storeIndexes(pkgs, listed, stale)
+			}
+
+			p.mu.Lock()
+			for _, path := range batch {
+				if p.cache.filling[path] != stale[path] {
+					continue
+				}
+				delete(p.cache.filling, path)
+				if idx, ok := indexes[path]; ok && p.cache.keys[path] == stale[path] {
+					p.cache.cached[path] = idx
+				}
+			}
+			p.generation++
+			p.mu.Unlock()
+		}
+	}()
+}
+
+// storeIndexes extracts the indexes of the loaded packages that have a key, and caches them unless
+// the package has errors or the files they are keyed on changed while loading it.
+func (p *Parser) storeIndexes(pkgs []*packages.Package, listed map[string]*packages.Package, keys map[string]string) map[string]*packageIndex {
+	// Hash the listed packages the loaded ones depend on again, to compare with the files they were keyed on
+	p.mu.RLock()
+	env, hashes := p.cache.env, maps.Clone(p.cache.hashes)
+	p.mu.RUnlock()
+	for _, pkg := range withDependencies(pkgs) {
+		if l, ok := listed[pkg.PkgPath]; ok {
+			hash, ok := hashFiles(l)
+			if !ok {
+				delete(hashes, pkg.PkgPath)
+				continue
+			}
+			hashes[pkg.PkgPath] = hash
+		}
+	}
+	current := indexKeys(listed, hashes, env)
+
+	indexes := make(map[string]*packageIndex, len(pkgs))
+	for _, pkg := range pkgs {
+		key, ok := keys[pkg.PkgPath]
+		if !ok || pkg.Types == nil {
+			continue
+		}
+		idx := p.extractIndex(pkg)
+		indexes[pkg.PkgPath] = idx
+		if len(pkg.Errors) > 0 || current[pkg.PkgPath] != key {
+			continue
+		}
+		if err := p.opts.Cache.Put(key, idx); err != nil {
+			log.Printf("Failed to cache the index of %s: %v", pkg.PkgPath, err)
+		}
+	}
+	return indexes
+}
+
+// indexPosition returns the source position of an indexed symbol as "file:line".
+func (p *Parser) indexPosition(s *indexSymbol) string {
+	if s.File == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", p.relPath(s.File), s.Line)
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// InterfaceInfo represents information about an interface
+type InterfaceInfo struct {
+	Name            string            // Interface name
+	Comment         string            // Interface comment
+	Methods         []InterfaceMethod // Method set, including methods of embedded interfaces
+	Embeddeds       []string          // Embedded interfaces
+	TypeSet         []string          // Types permitted by a constraint interface, with embedded constraints expanded
+	IsConstraint    bool              // Whether the interface can only be used as a type constraint
+	Implementations []Implementation  // Types in the loaded packages that implement the interface
+	Examples        []Example         // Interface examples
+	Declaration     string            // Go declaration of the interface
+	TypeParams      []TypeParam       // Type parameters of a generic interface
+	Instantiations  []Instantiation   // Instantiations of a generic interface
+}
+
+// InterfaceMethod represents information about an interface method
+type InterfaceMethod struct {
+	Name         string // Method name
+	Signature    string // Method declaration
+	Comment      string // Method comment
+	EmbeddedFrom string // Embedded interface that declares the method, empty for explicit methods
+}
+
+// GetInterfaceInfo returns information about an interface in the specified package
+func (p *Parser) GetInterfaceInfo(pkgPath, interfaceName string) (*InterfaceInfo, error) {
+	pkg, err := p.GetPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get type information from the package
+	obj := pkg.Types.Scope().Lookup(interfaceName)
+	if obj == nil {
+		return nil, fmt.Errorf("interface not found: %s in package %s", interfaceName, pkgPath)
+	}
+
+	// Check if the type is an interface
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("not an interface: %s in package %s", interfaceName, pkgPath)
+	}
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("not an interface: %s in package %s", interfaceName, pkgPath)
+	}
+
+	// Build interface information
+	qf := qualifier(pkg.Types)
+	info := &InterfaceInfo{
+		Name:           interfaceName,
+		Comment:        p.GetComment(pkg, obj),
+		Methods:        make([]InterfaceMethod, 0, iface.NumMethods()),
+		IsConstraint:   !iface.IsMethodSet(),
+		Examples:       p.GetExamples(pkg, interfaceName),
+		Declaration:    declaration(pkg, obj),
+		TypeParams:     getTypeParams(named.TypeParams(), qf),
+		Instantiations: make([]Instantiation, 0),
+	}
+	if named.TypeParams().Len() > 0 {
+		info.Instantiations = p.getInstantiations(obj)
+	}
+
+	// Get embedded interfaces and the type set of constraint interfaces
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		if embedded := iface.EmbeddedType(i); types.IsInterface(embedded) {
+			info.Embeddeds = append(info.Embeddeds, types.TypeString(embedded, qf))
+		}
+	}
+	if info.IsConstraint {
+		info.TypeSet = constraintTypeSet(named, qf)
+	}
+
+	// Get method information, including methods promoted from embedded interfaces
+	explicit := make(map[string]bool)
+	for i := 0; i < iface.NumExplicitMethods(); i++ {
+		explicit[iface.ExplicitMethod(i).Name()] = true
+	}
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		m := InterfaceMethod{
+			Name:      method.Name(),
+			Signature: typesDeclaration(method),
+			Comment:   p.GetComment(p.packageOf(pkg, method), method),
+		}
+		if !explicit[method.Name()] {
+			m.EmbeddedFrom = embeddedFrom(iface, method.Name(), qf)
+		}
+		info.Methods = append(info.Methods, m)
+	}
+
+	// Find implementations in the loaded packages
+	// Implementations of generic interfaces depend on their type arguments and are not searched.
+	info.Implementations = make([]Implementation, 0)
+	if named.TypeParams().Len() == 0 {
+		info.Implementations = p.findImplementations(named, iface, p.GetAllPackages())
+	}
+
+	return info, nil
+}
+
+// embeddedFrom returns the embedded interface that provides the named method, qualified with qf.
+func embeddedFrom(iface *types.Interface, methodName string, qf types.Qualifier) string {
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		embedded := iface.EmbeddedType(i)
+		ei, ok := embedded.Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+		for j := 0; j < ei.NumMethods(); j++ {
+			if ei.Method(j).Name() == methodName {
+				return types.TypeString(embedded, qf)
+			}
+		}
+	}
+	return ""
+}
+
+// packageOf returns the package declaring obj, or from if the package is not found.
+func (p *Parser) packageOf(from *packages.Package, obj types.Object) *packages.Package {
+	if obj.Pkg() == nil || obj.Pkg().Path() == from.PkgPath {
+		return from
+	}
+	if pkg, err := p.GetPackage(obj.Pkg().Path()); err == nil {
+		return pkg
+	}
+	return from
+}
+
+// position returns the source position as "file:line".
+// Files under the root directory are reported relative to it.
+func (p *Parser) position(fset *token.FileSet, pos token.Pos) string {
+	if !pos.IsValid() {
+		return ""
+	}
+	position := fset.Position(pos)
+	return fmt.Sprintf("%s:%d", p.relPath(position.Filename), position.Line)
+}
+
+// relPath returns filename relative to the base directory if it is under it, using forward slashes.
+// The base directory is the root directory unless the parser is part of a workspace.
+func (p *Parser) relPath(filename string) string {
+	p.mu.RLock()
+	baseDir := p.baseDir
+	p.mu.RUnlock()
+	if rel, err := filepath.Rel(baseDir, filename); err == nil && !strings.HasPrefix(rel, "..") {
+		filename = rel
+	}
+	return filepath.ToSlash(filename)
+}
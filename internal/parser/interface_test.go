@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetInterfaceInfo(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"store/store.go": `package store
+
+import "io"
+
+type (
+	// Store persists values.
+	Store interface {
+		io.Closer
+		// Get returns a value.
+		Get(key string) (string, error)
+	}
+
+	// Number is a constraint for numbers.
+	Number interface {
+		~int | ~float64
+	}
+)
+
+type MemStore struct{}
+
+func (MemStore) Get(key string) (string, error) { return "", nil }
+func (*MemStore) Close() error                  { return nil }
+
+type Celsius float64
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	t.Run("method set", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetInterfaceInfo("example.com/m/store", "Store")
+		if err != nil {
+			t.Fatalf("GetInterfaceInfo() error = %v", err)
+		}
+		if info.Comment != "Store persists values." {
+			t.Errorf("Comment = %q, want %q", info.Comment, "Store persists values.")
+		}
+		if info.IsConstraint {
+			t.Error("IsConstraint = true, want false")
+		}
+		if want := []string{"io.Closer"}; !reflect.DeepEqual(info.Embeddeds, want) {
+			t.Errorf("Embeddeds = %v, want %v", info.Embeddeds, want)
+		}
+		want := []InterfaceMethod{
+			{Name: "Close", Signature: "func Close() error", Comment: "", EmbeddedFrom: "io.Closer"},
+			{Name: "Get", Signature: "func Get(key string) (string, error)", Comment: "Get returns a value."},
+		}
+		// Comments of standard library methods depend on the Go version
+		for i := range info.Methods {
+			if info.Methods[i].EmbeddedFrom != "" {
+				info.Methods[i].Comment = ""
+			}
+		}
+		if !reflect.DeepEqual(info.Methods, want) {
+			t.Errorf("Methods = %+v, want %+v", info.Methods, want)
+		}
+		wantImpl := []Implementation{
+			{Name: "MemStore", PkgPath: "example.com/m/store", Position: "store/store.go:19", IsPointer: true},
+		}
+		if !reflect.DeepEqual(info.Implementations, wantImpl) {
+			t.Errorf("Implementations = %+v, want %+v", info.Implementations, wantImpl)
+		}
+	})
+
+	t.Run("constraint", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := p.GetInterfaceInfo("example.com/m/store", "Number")
+		if err != nil {
+			t.Fatalf("GetInterfaceInfo() error = %v", err)
+		}
+		if !info.IsConstraint {
+			t.Error("IsConstraint = false, want true")
+		}
+		if want := []string{"~int", "~float64"}; !reflect.DeepEqual(info.TypeSet, want) {
+			t.Errorf("TypeSet = %v, want %v", info.TypeSet, want)
+		}
+		if len(info.Implementations) != 1 || info.Implementations[0].Name != "Celsius" {
+			t.Errorf("Implementations = %+v, want Celsius", info.Implementations)
+		}
+	})
+
+	t.Run("not an interface", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := p.GetInterfaceInfo("example.com/m/store", "MemStore"); err == nil {
+			t.Error("GetInterfaceInfo() error = nil, want error")
+		}
+	})
+}
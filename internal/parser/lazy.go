@@ -0,0 +1,288 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/tools/go/packages"
+)
+
+// lazyPackages holds the packages under the root directory in lazy mode, which are listed up front
+// and type-checked on request. Its maps are guarded by Parser.mu.
+type lazyPackages struct {
+	loads  singleflight.Group           // Loads of packages in flight, by package path
+	listed map[string]*packages.Package // Metadata of every package under the root directory
+	used   map[string]uint64            // Clock value of the last use of each cached package
+	clock  uint64                       // Incremented on every use of a cached package
+}
+
+// listPackages lists the packages under rootDir with their names, files and imports, without type-checking them.
+// Imported packages only have their path.
+func listPackages(rootDir string) (map[string]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports,
+		Dir:  rootDir,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w", err)
+	}
+
+	listed := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		listed[pkg.PkgPath] = pkg
+	}
+	return listed, nil
+}
+
+// ListPackages returns the packages under the root directory, sorted by package path.
+// In lazy mode, packages that have not been type-checked yet only have their name, files, imports
+// and listing errors.
+func (p *Parser) ListPackages() []*packages.Package {
+	p.mu.RLock()
+	all := make(map[string]*packages.Package, len(p.lazy.listed)+len(p.pkgs))
+	maps.Copy(all, p.lazy.listed)
+	maps.Copy(all, p.pkgs)
+	p.mu.RUnlock()
+
+	result := make([]*packages.Package, 0, len(all))
+	for _, pkg := range all {
+		result = append(result, pkg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PkgPath < result[j].PkgPath
+	})
+	return result
+}
+
+// isListed reports whether the package is under the root directory but has not been type-checked in lazy mode.
+func (p *Parser) isListed(pkgPath string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, ok := p.lazy.listed[pkgPath]
+	return ok
+}
+
+// touch records a use of a cached package, so that it is released after the packages used before it.
+func (p *Parser) touch(pkgPath string) {
+	if !p.opts.Lazy {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lazy.clock++
+	p.lazy.used[pkgPath] = p.lazy.clock
+}
+
+// loadLocal type-checks a package under the root directory in lazy mode and caches it,
+// releasing the least recently used packages beyond Options.MaxLocalPackages.
+// Each package is loaded with its own dependencies, so packages loaded separately have distinct
+// types for their common dependencies, which are matched by package path and name across loads.
+// Concurrent requests for the same package share a single load.
+func (p *Parser) loadLocal(pkgPath string) (*packages.Package, error) {
+	v, err, _ := p.lazy.loads.Do(pkgPath, func() (any, error) {
+		for {
+			p.mu.RLock()
+			pkg, ok := p.pkgs[pkgPath]
+			sources := p.sources
+			p.mu.RUnlock()
+			// Another load may have cached the package meanwhile
+			if ok {
+				p.touch(pkgPath)
+				return pkg, nil
+			}
+
+			pkgs, err := packages.Load(loadConfig(p.rootDir), pkgPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load package %s: %w", pkgPath, err)
+			}
+			// Packages whose files were all removed come back without any files
+			if len(pkgs) != 1 || len(pkgs[0].GoFiles) == 0 {
+				return nil, fmt.Errorf("package not found: %s", pkgPath)
+			}
+			// A package loaded while source files were reloaded may be stale, so it is loaded again
+			if p.cacheLocal(pkgs[0], sources) {
+				return pkgs[0], nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*packages.Package), nil
+}
+
+// cacheLocal adds pkg to the cached packages, releasing the least recently used packages beyond
+// Options.MaxLocalPackages. It reports false without caching pkg if source files were reloaded
+// since sources, the value of p.sources before loading it.
+func (p *Parser) cacheLocal(pkg *packages.Package, sources uint64) bool {
+	p.update.Lock()
+	defer p.update.Unlock()
+
+	p.mu.RLock()
+	current := p.pkgs
+	reloaded := p.sources != sources
+	p.mu.RUnlock()
+	if reloaded {
+		return false
+	}
+
+	p.touch(pkg.PkgPath)
+	keep := make([]string, 0, len(current))
+	p.mu.RLock()
+	for path := range current {
+		keep = append(keep, path)
+	}
+	// Most recently used first, so that the packages beyond the limit are the least recently used
+	sort.Slice(keep, func(i, j int) bool {
+		return p.lazy.used[keep[i]] > p.lazy.used[keep[j]]
+	})
+	p.mu.RUnlock()
+	if limit := p.opts.MaxLocalPackages; limit > 0 && len(keep) >= limit {
+		keep = keep[:limit-1]
+	}
+
+	next := make(map[string]*packages.Package, len(keep)+1)
+	for _, path := range keep {
+		next[path] = current[path]
+	}
+	next[pkg.PkgPath] = pkg
+	p.setPackages(next, false)
+
+	return true
+}
+
+// relatedPackages returns the paths of the listed packages that import pkgPath or that pkgPath
+// imports, directly or transitively, and pkgPath itself, sorted.
+// These are the packages the calls from and to the package can go through under the root directory.
+func (p *Parser) relatedPackages(pkgPath string) []string {
+	p.mu.RLock()
+	listed := p.lazy.listed
+	p.mu.RUnlock()
+
+	list := make([]*packages.Package, 0, len(listed))
+	for _, pkg := range listed {
+		list = append(list, pkg)
+	}
+	related := importersOf(list, pkgPath)
+	queue := []string{pkgPath}
+	seen := map[string]bool{pkgPath: true}
+	for len(queue) > 0 {
+		pkg, ok := listed[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+		for _, imp := range pkg.Imports {
+			if _, ok := listed[imp.PkgPath]; ok && !seen[imp.PkgPath] {
+				seen[imp.PkgPath] = true
+				related[imp.PkgPath] = true
+				queue = append(queue, imp.PkgPath)
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(related))
+	for path := range related {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// testImporters returns the paths of the packages under the root directory that import pkgPath
+// directly or transitively, from their files or their test files, and pkgPath itself, sorted.
+// Loading them with their tests loads every file that can refer to the package.
+func (p *Parser) testImporters(pkgPath string) ([]string, error) {
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedImports | packages.NeedForTest,
+		Dir:   p.rootDir,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w", err)
+	}
+
+	related := importersOf(pkgs, pkgPath)
+	seen := make(map[string]bool)
+	paths := make([]string, 0, len(related))
+	for _, pkg := range pkgs {
+		// Generated test main packages only refer to the test functions
+		if !related[pkg.PkgPath] || strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		// Test variants and external test packages are loaded with the package they test
+		path := pkg.PkgPath
+		if pkg.ForTest != "" {
+			path = pkg.ForTest
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// importersOf returns the paths of pkgs that import pkgPath, directly or transitively, and pkgPath itself.
+func importersOf(pkgs []*packages.Package, pkgPath string) map[string]bool {
+	importers := make(map[string][]string)
+	for _, pkg := range pkgs {
+		for _, imp := range pkg.Imports {
+			importers[imp.PkgPath] = append(importers[imp.PkgPath], pkg.PkgPath)
+		}
+	}
+
+	result := map[string]bool{pkgPath: true}
+	queue := []string{pkgPath}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		for _, importer := range importers[path] {
+			if !result[importer] {
+				result[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+	return result
+}
+
+// relist lists the packages under the root directory again in lazy mode, and releases the
+// cached packages in affected, or every cached package if affected is nil.
+// Released packages are type-checked again when next requested. The search indexes of the
+// packages in dirs, or of every package if dirs is nil, are refreshed from the index cache,
+// and the missing ones filled in the background.
+// The caller must hold p.update.
+func (p *Parser) relist(current map[string]*packages.Package, affected, dirs map[string]bool) error {
+	listed, err := listPackages(p.rootDir)
+	if err != nil {
+		return fmt.Errorf("failed to reload packages: %w", err)
+	}
+
+	next := make(map[string]*packages.Package, len(current))
+	for path, pkg := range current {
+		if _, ok := listed[path]; ok && affected != nil && !affected[path] {
+			next[path] = pkg
+		}
+	}
+
+	cached, stale := p.refreshIndexes(listed, dirs)
+
+	p.mu.Lock()
+	p.lazy.listed = listed
+	p.cache.cached = cached
+	p.mu.Unlock()
+	p.setPackages(next, true)
+	p.fillIndexes(listed, stale)
+
+	return nil
+}
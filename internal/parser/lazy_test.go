@@ -0,0 +1,342 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/budougumi0617/godoc-mcp/internal/diskcache"
+)
+
+func TestParser_Lazy(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"a/a.go": "// Package a is loaded on request.\npackage a\n\n// A is a type.\ntype A struct{}\n",
+		"b/b.go": "package b\n\n// B is a type.\ntype B struct{}\n",
+	})
+	p, err := NewWithOptions(dir, Options{Lazy: true, MaxLocalPackages: 1})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	// Packages are listed without being type-checked
+	if got := len(p.GetAllPackages()); got != 0 {
+		t.Errorf("GetAllPackages() before any request has %d packages, want 0", got)
+	}
+	listed := p.ListPackages()
+	if len(listed) != 2 || listed[0].PkgPath != "example.com/m/a" || listed[1].PkgPath != "example.com/m/b" {
+		t.Fatalf("ListPackages() = %v, want example.com/m/a and example.com/m/b", listed)
+	}
+	if got := GetPackageComment(listed[0]); got != "Package a is loaded on request." {
+		t.Errorf("GetPackageComment() of a listed package = %q, want %q", got, "Package a is loaded on request.")
+	}
+	if !p.IsLocal("example.com/m/b") {
+		t.Errorf("IsLocal() of a listed package = false, want true")
+	}
+
+	// Packages are type-checked on first request and cached
+	a, err := p.GetPackage("example.com/m/a")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	if a.Types == nil || a.Types.Scope().Lookup("A") == nil {
+		t.Fatalf("GetPackage() returned a package that is not type-checked")
+	}
+	if again, _ := p.GetPackage("example.com/m/a"); again != a {
+		t.Errorf("GetPackage() loaded example.com/m/a again, want the cached package")
+	}
+
+	// The least recently used package is released beyond MaxLocalPackages
+	if _, err := p.GetPackage("example.com/m/b"); err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	if pkgs := p.GetAllPackages(); len(pkgs) != 1 || pkgs[0].PkgPath != "example.com/m/b" {
+		t.Errorf("GetAllPackages() = %v, want example.com/m/b only", pkgs)
+	}
+	if reloaded, _ := p.GetPackage("example.com/m/a"); reloaded == a {
+		t.Errorf("GetPackage() returned the released package")
+	}
+
+	// Changed packages are released, and new packages listed
+	writeFiles(t, dir, map[string]string{
+		"c/c.go": "package c\n",
+	})
+	if err := p.Reload([]string{filepath.Join(dir, "c", "c.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if !p.IsLocal("example.com/m/c") {
+		t.Errorf("IsLocal() of a new package = false, want true")
+	}
+	if _, err := p.GetPackage("example.com/m/c"); err != nil {
+		t.Errorf("GetPackage() of a new package error = %v", err)
+	}
+}
+
+func TestParser_LazyIndexCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := diskcache.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("diskcache.New() error = %v", err)
+	}
+	dir := writeModule(t, map[string]string{
+		"a/a.go": "package a\n\n// Alpha does things.\nfunc Alpha() {}\n",
+		"b/b.go": "package b\n\n// Beta does other things.\nfunc Beta(n int) error { return nil }\n",
+	})
+	opts := Options{Lazy: true, MaxLocalPackages: 1, Cache: cache}
+
+	// The first start indexes every package in the background, one package at a time
+	first, err := NewWithOptions(dir, opts)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	first.cache.fills.Wait()
+
+	// Packages whose files did not change are searched from the cache without type-checking them
+	writeFiles(t, dir, map[string]string{
+		"a/a.go": "package a\n\n// Alpha does things differently.\nfunc Alpha(s string) {}\n",
+	})
+	p, err := NewWithOptions(dir, opts)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	p.mu.RLock()
+	_, unchanged := p.cache.cached["example.com/m/b"]
+	_, changed := p.cache.cached["example.com/m/a"]
+	p.mu.RUnlock()
+	if !unchanged || changed {
+		t.Errorf("cached indexes after restart have b %v and a %v, want b only", unchanged, changed)
+	}
+	p.cache.fills.Wait()
+	if got := len(p.GetAllPackages()); got != 0 {
+		t.Errorf("GetAllPackages() after restart has %d packages, want 0", got)
+	}
+
+	tests := map[string]struct {
+		query string
+		want  Symbol
+	}{
+		"unchanged package": {
+			query: "Beta",
+			want: Symbol{
+				Name:      "Beta",
+				Kind:      SymbolKindFunc,
+				PkgPath:   "example.com/m/b",
+				Signature: "func Beta(n int) error",
+				Summary:   "Beta does other things.",
+				Position:  "b/b.go:4",
+				Exported:  true,
+				Score:     scoreExact + 1 + scoreExported,
+			},
+		},
+		"changed package": {
+			query: "Alpha",
+			want: Symbol{
+				Name:      "Alpha",
+				Kind:      SymbolKindFunc,
+				PkgPath:   "example.com/m/a",
+				Signature: "func Alpha(s string)",
+				Summary:   "Alpha does things differently.",
+				Position:  "a/a.go:4",
+				Exported:  true,
+				Score:     scoreExact + 1 + scoreExported,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			symbols := p.SearchSymbols(tt.query, "", 1)
+			if len(symbols) != 1 || symbols[0] != tt.want {
+				t.Errorf("SearchSymbols(%q) = %+v, want %+v", tt.query, symbols, tt.want)
+			}
+		})
+	}
+
+	if matches := p.SearchDocs("other things", 1); len(matches) != 1 || matches[0].Name != "Beta" {
+		t.Errorf("SearchDocs() = %+v, want Beta", matches)
+	}
+}
+
+func TestParser_LazyImplements(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"api/api.go":   "package api\n\nimport \"context\"\n\ntype Handler interface {\n\tHandle(ctx context.Context) error\n}\n",
+		"impl/impl.go": "package impl\n\nimport \"context\"\n\ntype Impl struct{}\n\nfunc (Impl) Handle(ctx context.Context) error { return nil }\n",
+	})
+	p, err := NewWithOptions(dir, Options{Lazy: true})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	// Packages loaded on separate requests must share the types of their common dependencies
+	for _, path := range []string{"example.com/m/api", "example.com/m/impl"} {
+		if _, err := p.GetPackage(path); err != nil {
+			t.Fatalf("GetPackage(%q) error = %v", path, err)
+		}
+	}
+
+	info, err := p.GetImplementsInfo("example.com/m/api", "Handler", false)
+	if err != nil {
+		t.Fatalf("GetImplementsInfo() error = %v", err)
+	}
+	var got []string
+	for _, impl := range info.Implementations {
+		got = append(got, impl.PkgPath+"."+impl.Name)
+	}
+	if want := []string{"example.com/m/impl.Impl"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Implementations of lazily loaded packages = %v, want %v", got, want)
+	}
+}
+
+func TestParser_LazyCallersAndReferences(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"api/api.go":          "package api\n\n// Do does things.\nfunc Do() int { return 1 }\n",
+		"use/use.go":          "package use\n\nimport \"example.com/m/api\"\n\n// Run runs.\nfunc Run() int { return api.Do() }\n",
+		"check/check.go":      "package check\n",
+		"check/check_test.go": "package check\n\nimport (\n\t\"testing\"\n\n\t\"example.com/m/api\"\n)\n\nfunc TestDo(t *testing.T) { api.Do() }\n",
+		"other/other.go":      "package other\n\n// Other is unrelated.\nfunc Other() {}\n",
+	})
+	p, err := NewWithOptions(dir, Options{Lazy: true})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	// Calls are found through the packages importing the queried package only
+	calls, err := p.GetCallers("example.com/m/api", "Do", CallGraphStatic, 1)
+	if err != nil {
+		t.Fatalf("GetCallers() error = %v", err)
+	}
+	if len(calls) != 1 || calls[0].Caller != "example.com/m/use.Run" {
+		t.Errorf("GetCallers() = %+v, want a call from example.com/m/use.Run", calls)
+	}
+	p.mu.RLock()
+	local := p.calls.local
+	p.mu.RUnlock()
+	if local["example.com/m/other"] || local["example.com/m/check"] {
+		t.Errorf("call graph packages = %v, want example.com/m/api and its importers only", local)
+	}
+
+	// References are found in the importers of the package, including their test files
+	info, err := p.FindReferences("example.com/m/api", "Do")
+	if err != nil {
+		t.Fatalf("FindReferences() error = %v", err)
+	}
+	var files []string
+	for _, ref := range info.References {
+		files = append(files, ref.File)
+	}
+	if want := []string{"check/check_test.go", "use/use.go"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("FindReferences() files = %v, want %v", files, want)
+	}
+	p.mu.RLock()
+	tests := p.tests.pkgs
+	p.mu.RUnlock()
+	for _, pkg := range tests {
+		if pkg.PkgPath == "example.com/m/other" {
+			t.Errorf("FindReferences() loaded example.com/m/other, which does not import example.com/m/api")
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"a/a.go": "package a\n\nimport \"example.com/m/b\"\n\nfunc A() b.B { return 0 }\n",
+		"b/b.go": "package b\n\ntype B int\n",
+		"c/c.go": "package c\n",
+	})
+	keysOf := func(env *indexEnv) map[string]string {
+		t.Helper()
+		listed, err := listPackages(dir)
+		if err != nil {
+			t.Fatalf("listPackages() error = %v", err)
+		}
+		hashes := make(map[string]string)
+		for path, pkg := range listed {
+			if hash, ok := hashFiles(pkg); ok {
+				hashes[path] = hash
+			}
+		}
+		return indexKeys(listed, hashes, env)
+	}
+	env := &indexEnv{goVersion: "go1.24.0"}
+	before := keysOf(env)
+	if len(before) != 3 {
+		t.Fatalf("indexKeys() = %v, want a key for every package", before)
+	}
+
+	// A change to an imported package changes the signatures of its importers
+	writeFiles(t, dir, map[string]string{
+		"b/b.go": "package b\n\ntype B string\n",
+	})
+	after := keysOf(env)
+	other := keysOf(&indexEnv{goVersion: "go1.25.0"})
+	tests := map[string]struct {
+		keys    map[string]string
+		pkgPath string
+		changed bool
+	}{
+		"changed package":   {keys: after, pkgPath: "example.com/m/b", changed: true},
+		"importer":          {keys: after, pkgPath: "example.com/m/a", changed: true},
+		"unrelated":         {keys: after, pkgPath: "example.com/m/c", changed: false},
+		"another toolchain": {keys: other, pkgPath: "example.com/m/c", changed: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if changed := tt.keys[tt.pkgPath] != before[tt.pkgPath]; changed != tt.changed {
+				t.Errorf("key of %s changed = %v, want %v", tt.pkgPath, changed, tt.changed)
+			}
+		})
+	}
+}
+
+func TestParser_LazyIndexCacheReload(t *testing.T) {
+	t.Parallel()
+
+	cache, err := diskcache.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("diskcache.New() error = %v", err)
+	}
+	dir := writeModule(t, map[string]string{
+		"a/a.go": "package a\n\n// Alpha does things.\nfunc Alpha() {}\n",
+		"b/b.go": "package b\n\n// Beta does other things.\nfunc Beta() {}\n",
+	})
+	p, err := NewWithOptions(dir, Options{Lazy: true, Cache: cache})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	p.cache.fills.Wait()
+
+	// Only the packages in the directories of the changed files are hashed again
+	p.mu.RLock()
+	hash := p.cache.hashes["example.com/m/b"]
+	p.mu.RUnlock()
+	writeFiles(t, dir, map[string]string{
+		"a/a.go": "package a\n\n// Alpha does things once more.\nfunc Alpha() {}\n",
+		"b/b.go": "package b\n\n// Beta is not reported as changed.\nfunc Beta() {}\n",
+	})
+	if err := p.Reload([]string{filepath.Join(dir, "a", "a.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	p.cache.fills.Wait()
+	p.mu.RLock()
+	unchangedHash := p.cache.hashes["example.com/m/b"] == hash
+	idx := p.cache.cached["example.com/m/a"]
+	p.mu.RUnlock()
+	if !unchangedHash {
+		t.Errorf("Reload() hashed example.com/m/b again, want only example.com/m/a")
+	}
+	if idx == nil || idx.Symbols[0].Comment != "Alpha does things once more." {
+		t.Errorf("cached index of example.com/m/a after Reload() = %+v, want the changed comment", idx)
+	}
+}
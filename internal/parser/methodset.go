@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// methodSet returns the methods callable on an addressable value of named, which is declared in pkg:
+// its declared methods and the methods promoted from embedded fields.
+// Methods that are only in the method set of the pointer type are marked as such.
+func (p *Parser) methodSet(pkg *packages.Package, named *types.Named) []Method {
+	qf := qualifier(pkg.Types)
+	valueSet := types.NewMethodSet(named)
+	selections := typeutil.IntuitiveMethodSet(named, nil)
+
+	methods := make([]Method, 0, len(selections))
+	for _, sel := range selections {
+		fn, ok := sel.Obj().(*types.Func)
+		if !ok {
+			continue
+		}
+		declPkg := p.packageOf(pkg, fn)
+		method := Method{
+			Name:            fn.Name(),
+			Signature:       declaration(declPkg, fn),
+			Comment:         p.GetComment(declPkg, fn),
+			PointerReceiver: valueSet.Lookup(fn.Pkg(), fn.Name()) == nil,
+		}
+		if len(sel.Index()) > 1 {
+			method.PromotedFrom = types.TypeString(embeddedType(named, sel.Index()), qf)
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// methodLookup is a method found by lookupMethod.
+type methodLookup struct {
+	fn   *types.Func
+	recv *types.Named // Type declaring fn, which is an embedded type for promoted methods
+}
+
+// lookupMethod returns the method name callable on an addressable value of named,
+// either declared by named or promoted from an embedded field.
+func lookupMethod(named *types.Named, pkg *types.Package, name string) (methodLookup, bool) {
+	obj, index, _ := types.LookupFieldOrMethod(named, true, pkg, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return methodLookup{}, false
+	}
+	recv := named
+	if len(index) > 1 {
+		if n, ok := embeddedType(named, index).(*types.Named); ok {
+			recv = n
+		}
+	}
+	return methodLookup{fn: fn, recv: recv}, true
+}
+
+// embeddedType follows the embedded fields on path, the index of a promoted method selection,
+// and returns the type of the last one, which declares the method.
+func embeddedType(t types.Type, path []int) types.Type {
+	for _, i := range path[:len(path)-1] {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return t
+		}
+		t = st.Field(i).Type()
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	return t
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetStructInfo_embedding(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"file/file.go": `package file
+
+import "io"
+
+// Base is embedded by File.
+type Base struct {
+	// ID of the file
+	ID int
+}
+
+// Describe describes the file.
+func (b Base) Describe() string { return "" }
+
+// Touch updates the file.
+func (b *Base) Touch() {}
+
+// File is a file.
+type File struct {
+	Base
+	io.Reader
+	Name string
+}
+
+// Close closes the file.
+func (f *File) Close() error { return nil }
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	info, err := p.GetStructInfo("example.com/m/file", "File")
+	if err != nil {
+		t.Fatalf("GetStructInfo() error = %v", err)
+	}
+
+	wantFields := []Field{
+		{Name: "Base", Type: "Base", IsExported: true, Embedded: true},
+		{Name: "Reader", Type: "io.Reader", IsExported: true, Embedded: true},
+		{Name: "Name", Type: "string", IsExported: true},
+	}
+	for i := range info.Fields {
+		info.Fields[i].Layout = nil
+	}
+	if !reflect.DeepEqual(info.Fields, wantFields) {
+		t.Errorf("Fields = %+v, want %+v", info.Fields, wantFields)
+	}
+
+	wantMethods := []Method{
+		{Name: "Close", Signature: "func (f *File) Close() error", Comment: "Close closes the file.", PointerReceiver: true},
+		{Name: "Describe", Signature: "func (b Base) Describe() string", Comment: "Describe describes the file.", PromotedFrom: "Base"},
+		{Name: "Read", Signature: "func Read(p []byte) (n int, err error)", PromotedFrom: "io.Reader"},
+		{Name: "Touch", Signature: "func (b *Base) Touch()", Comment: "Touch updates the file.", PromotedFrom: "Base", PointerReceiver: true},
+	}
+	if !reflect.DeepEqual(info.Methods, wantMethods) {
+		t.Errorf("Methods = %+v, want %+v", info.Methods, wantMethods)
+	}
+
+	// Promoted methods are found as well
+	for _, want := range wantMethods {
+		got, err := p.GetMethodInfo("example.com/m/file", "File", want.Name)
+		if err != nil {
+			t.Errorf("GetMethodInfo(%s) error = %v", want.Name, err)
+			continue
+		}
+		if got.Signature != want.Signature || got.Comment != want.Comment || got.PromotedFrom != want.PromotedFrom || got.PointerReceiver != want.PointerReceiver {
+			t.Errorf("GetMethodInfo(%s) = %+v, want %+v", want.Name, *got, want)
+		}
+	}
+	if _, err := p.GetMethodInfo("example.com/m/file", "File", "ID"); err == nil {
+		t.Error("GetMethodInfo(ID) error = nil, want error for a field")
+	}
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// PackageDoc represents the exported declarations of a package grouped the way go doc groups them
+type PackageDoc struct {
+	Consts []ValueGroup // Constant groups not associated with a type
+	Vars   []ValueGroup // Variable groups not associated with a type
+	Funcs  []FuncEntry  // Functions not associated with a type
+	Types  []TypeGroup  // Types with their associated declarations
+}
+
+// ValueGroup represents a constant or variable declaration group
+type ValueGroup struct {
+	Names   []string // Exported names declared in the group
+	Comment string   // Group comment, or the comment of its only name
+}
+
+// FuncEntry represents a function or method in a package listing
+type FuncEntry struct {
+	Name    string // Function or method name
+	Comment string // Function or method comment
+}
+
+// TypeGroup represents a type with the declarations go doc associates with it
+type TypeGroup struct {
+	Name         string       // Type name
+	Kind         string       // Kind of the type, as returned by TypeKind
+	Comment      string       // Type comment
+	Consts       []ValueGroup // Constant groups of the type
+	Vars         []ValueGroup // Variable groups of the type
+	Constructors []FuncEntry  // Functions returning the type
+	Methods      []FuncEntry  // Methods of the type
+}
+
+// GetPackageDoc returns the exported declarations of the specified package, grouped by go/doc:
+// typed constants and variables, and functions returning a type, are listed under that type.
+func (p *Parser) GetPackageDoc(pkgPath string) (*PackageDoc, error) {
+	pkg, err := p.GetPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// All declarations are read so that the shared syntax trees are not filtered in place
+	dpkg, err := doc.NewFromFiles(pkg.Fset, pkg.Syntax, pkg.PkgPath, doc.AllDecls|doc.PreserveAST)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package documentation: %w", err)
+	}
+
+	result := &PackageDoc{
+		Consts: p.valueGroups(pkg, dpkg.Consts),
+		Vars:   p.valueGroups(pkg, dpkg.Vars),
+		Funcs:  p.funcEntries(pkg, dpkg.Funcs),
+		Types:  make([]TypeGroup, 0, len(dpkg.Types)),
+	}
+	for _, t := range dpkg.Types {
+		// Declarations associated with an unexported type are listed at package level, as go doc does
+		if !ast.IsExported(t.Name) {
+			result.Consts = append(result.Consts, p.valueGroups(pkg, t.Consts)...)
+			result.Vars = append(result.Vars, p.valueGroups(pkg, t.Vars)...)
+			result.Funcs = append(result.Funcs, p.funcEntries(pkg, t.Funcs)...)
+			continue
+		}
+		obj, ok := pkg.Types.Scope().Lookup(t.Name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		result.Types = append(result.Types, TypeGroup{
+			Name:         t.Name,
+			Kind:         TypeKind(obj),
+			Comment:      p.GetComment(pkg, obj),
+			Consts:       p.valueGroups(pkg, t.Consts),
+			Vars:         p.valueGroups(pkg, t.Vars),
+			Constructors: p.funcEntries(pkg, t.Funcs),
+			Methods:      p.methodEntries(pkg, obj, t.Methods),
+		})
+	}
+	return result, nil
+}
+
+// valueGroups returns the groups of values that declare exported names.
+func (p *Parser) valueGroups(pkg *packages.Package, values []*doc.Value) []ValueGroup {
+	groups := make([]ValueGroup, 0, len(values))
+	scope := pkg.Types.Scope()
+	for _, v := range values {
+		group := ValueGroup{Names: make([]string, 0, len(v.Names)), Comment: strings.TrimSpace(v.Doc)}
+		for _, name := range v.Names {
+			if ast.IsExported(name) {
+				group.Names = append(group.Names, name)
+			}
+		}
+		if len(group.Names) == 0 {
+			continue
+		}
+		if group.Comment == "" && len(group.Names) == 1 {
+			if obj := scope.Lookup(group.Names[0]); obj != nil {
+				group.Comment = p.GetComment(pkg, obj)
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// funcEntries returns the exported package-level functions among funcs.
+func (p *Parser) funcEntries(pkg *packages.Package, funcs []*doc.Func) []FuncEntry {
+	entries := make([]FuncEntry, 0, len(funcs))
+	scope := pkg.Types.Scope()
+	for _, f := range funcs {
+		if !ast.IsExported(f.Name) {
+			continue
+		}
+		entry := FuncEntry{Name: f.Name}
+		if obj := scope.Lookup(f.Name); obj != nil {
+			entry.Comment = p.GetComment(pkg, obj)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// methodEntries returns the exported methods among methods, which are declared on obj.
+func (p *Parser) methodEntries(pkg *packages.Package, obj *types.TypeName, methods []*doc.Func) []FuncEntry {
+	entries := make([]FuncEntry, 0, len(methods))
+	for _, m := range methods {
+		if !ast.IsExported(m.Name) {
+			continue
+		}
+		entry := FuncEntry{Name: m.Name}
+		if method, _, _ := types.LookupFieldOrMethod(obj.Type(), true, pkg.Types, m.Name); method != nil {
+			entry.Comment = p.GetComment(pkg, method)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
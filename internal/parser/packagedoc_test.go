@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetPackageDoc(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"shop/shop.go": `package shop
+
+// MaxItems is the maximum number of items.
+const MaxItems = 10
+
+// Status is an order status.
+type Status int
+
+// Order statuses.
+const (
+	Pending Status = iota
+	Shipped
+	canceled
+)
+
+// Order is an order.
+type Order struct {
+	Status Status
+}
+
+// NewOrder returns a pending order.
+func NewOrder() *Order { return &Order{} }
+
+// Ship ships the order.
+func (o *Order) Ship() { o.Status = Shipped }
+
+func (o *Order) reset() {}
+
+// Default is the default order.
+var Default Order
+
+// Total sums prices.
+func Total(prices ...int) int { return 0 }
+
+type cart struct{}
+
+// NewCart returns a cart, which is not exported.
+func NewCart() *cart { return &cart{} }
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := p.GetPackageDoc("example.com/m/shop")
+	if err != nil {
+		t.Fatalf("GetPackageDoc() error = %v", err)
+	}
+	want := &PackageDoc{
+		Consts: []ValueGroup{
+			{Names: []string{"MaxItems"}, Comment: "MaxItems is the maximum number of items."},
+		},
+		Vars: []ValueGroup{},
+		Funcs: []FuncEntry{
+			{Name: "Total", Comment: "Total sums prices."},
+			{Name: "NewCart", Comment: "NewCart returns a cart, which is not exported."},
+		},
+		Types: []TypeGroup{
+			{
+				Name:    "Order",
+				Kind:    "struct",
+				Comment: "Order is an order.",
+				Consts:  []ValueGroup{},
+				Vars: []ValueGroup{
+					{Names: []string{"Default"}, Comment: "Default is the default order."},
+				},
+				Constructors: []FuncEntry{
+					{Name: "NewOrder", Comment: "NewOrder returns a pending order."},
+				},
+				Methods: []FuncEntry{
+					{Name: "Ship", Comment: "Ship ships the order."},
+				},
+			},
+			{
+				Name:    "Status",
+				Kind:    "basic",
+				Comment: "Status is an order status.",
+				Consts: []ValueGroup{
+					{Names: []string{"Pending", "Shipped"}, Comment: "Order statuses."},
+				},
+				Vars:         []ValueGroup{},
+				Constructors: []FuncEntry{},
+				Methods:      []FuncEntry{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPackageDoc() = %+v, want %+v", got, want)
+	}
+}
@@ -2,26 +2,131 @@ package parser
 
 import (
 	"fmt"
-	"go/ast"
-	"go/format"
+	"go/parser"
 	"go/token"
 	"go/types"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/budougumi0617/godoc-mcp/internal/diskcache"
+	"golang.org/x/mod/modfile"
 	"golang.org/x/tools/go/packages"
 )
 
 // Parser is a structure that holds loaded package information
 type Parser struct {
-	pkgs map[string]*packages.Package
+	rootDir    string
+	baseDir    string        // Directory file paths are reported relative to
+	modulePath string        // Path of the module in the root directory, empty if there is no go.mod
+	goarch     func() string // GOARCH packages are loaded for, read on first use
+	opts       Options
+
+	update sync.Mutex // Serializes replacements of pkgs
+
+	mu             sync.RWMutex
+	pkgs           map[string]*packages.Package        // Type-checked packages under the root directory, the cached ones in lazy mode
+	lazy           lazyPackages                        // Listed packages and their uses in lazy mode
+	cache          indexCache                          // Search indexes of the listed packages from the index cache
+	indexes        map[*packages.Package]*packageIndex // Search indexes of the type-checked packages
+	generation     uint64                              // Incremented every time pkgs or the cached indexes are replaced
+	sources        uint64                              // Incremented every time source files are reloaded
+	docs           *docIndex                           // Doc comment index, built on first use
+	deps           map[string]*packages.Package        // Dependencies of pkgs, built on first use
+	depsGeneration uint64                              // Generation deps was built from
+	extra          map[string]*packages.Package        // Packages loaded on request
+	comments       map[*packages.Package]commentIndex  // Comment indexes of pkgs, deps and extra
+	examples       map[*packages.Package]exampleIndex  // Example indexes, built on first use
+	calls          *callGraphs                         // Call graphs, built on first use
+	tests          *testPackages                       // Packages with their test files, loaded on first use
+}
+
+// Options configures how a Parser loads packages.
+type Options struct {
+	// Lazy only lists the packages under the root directory up front, and type-checks
+	// each package the first time it is requested.
+	Lazy bool
+	// MaxLocalPackages is the number of packages under the root directory kept type-checked in
+	// lazy mode. The least recently used packages are released beyond it. Each package is loaded
+	// with its own dependencies, which are not counted. Zero keeps every package.
+	MaxLocalPackages int
+	// Cache stores the search indexes of packages in lazy mode, so that the packages that did not
+	// change since they were cached are searched without type-checking them. The indexes missing
+	// from it are filled in the background, type-checking at most MaxLocalPackages packages at a
+	// time. Nil disables it, and it is not used outside lazy mode.
+	Cache *diskcache.Cache
 }
 
 // New creates a Parser instance by loading Go packages from the specified directory.
 // rootDir is the base directory where packages will be loaded from.
 func New(rootDir string) (*Parser, error) {
-	patterns := []string{"./..."}
+	return NewWithOptions(rootDir, Options{})
+}
+
+// NewWithOptions creates a Parser instance loading Go packages from the specified directory as configured by opts.
+func NewWithOptions(rootDir string, opts Options) (*Parser, error) {
+	parser := &Parser{
+		rootDir:    rootDir,
+		baseDir:    rootDir,
+		modulePath: readModulePath(rootDir),
+		goarch:     sync.OnceValue(func() string { return readGoarch(rootDir) }),
+		opts:       opts,
+		pkgs:       make(map[string]*packages.Package),
+		indexes:    make(map[*packages.Package]*packageIndex),
+		extra:      make(map[string]*packages.Package),
+		examples:   make(map[*packages.Package]exampleIndex),
+		lazy:       lazyPackages{used: make(map[string]uint64)},
+		cache:      indexCache{filling: make(map[string]string)},
+	}
+	if opts.Lazy {
+		listed, err := listPackages(rootDir)
+		if err != nil {
+			return nil, err
+		}
+		parser.lazy.listed = listed
+		parser.comments = make(map[*packages.Package]commentIndex)
+		cached, stale := parser.refreshIndexes(listed, nil)
+		parser.cache.cached = cached
+		parser.fillIndexes(listed, stale)
+		return parser, nil
+	}
+	pkgs, err := packages.Load(loadConfig(rootDir), "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	// Store packages in the map
+	for _, pkg := range pkgs {
+		parser.pkgs[pkg.PkgPath] = pkg
+	}
+	parser.comments = indexComments(nil, pkgs)
+
+	return parser, nil
+}
 
-	cfg := &packages.Config{
+// readModulePath returns the module path declared by the go.mod file in dir, or an empty string if there is none.
+func readModulePath(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	return modfile.ModulePath(data)
+}
+
+// RootDir returns the directory packages are loaded from.
+func (p *Parser) RootDir() string {
+	return p.rootDir
+}
+
+// ModulePath returns the path of the module in the root directory, or an empty string if there is no go.mod.
+func (p *Parser) ModulePath() string {
+	return p.modulePath
+}
+
+// loadConfig returns the packages.Config used to load packages under rootDir.
+func loadConfig(rootDir string) *packages.Config {
+	return &packages.Config{
 		Mode: packages.NeedName |
 			packages.NeedFiles |
 			packages.NeedCompiledGoFiles |
@@ -29,30 +134,243 @@ func New(rootDir string) (*Parser, error) {
 			packages.NeedDeps |
 			packages.NeedTypes |
 			packages.NeedSyntax |
-			packages.NeedTypesInfo,
+			packages.NeedTypesInfo |
+			packages.NeedTypesSizes,
 		Dir:   rootDir,
 		Tests: false,
 	}
+}
+
+// Reload reloads the packages affected by the changed files.
+// Packages that have a changed file in their directory are reloaded together with
+// every loaded package that depends on them, directly or transitively, including through
+// dependencies such as the modules of a workspace. The other packages keep the types of
+// their dependencies from the previous load.
+// A change to go.mod or go.sum under the root directory, or to go.work, reloads all packages.
+// Tool calls in flight keep using the previous package map until the reload completes.
+func (p *Parser) Reload(changed []string) error {
+	p.update.Lock()
+	defer p.update.Unlock()
+
+	p.mu.RLock()
+	current := p.pkgs
+	p.mu.RUnlock()
+
+	dirs := make(map[string]bool)
+	for _, file := range changed {
+		switch filepath.Base(file) {
+		case "go.work", "go.work.sum":
+			return p.reloadAll()
+		case "go.mod", "go.sum":
+			if p.contains(file) {
+				return p.reloadAll()
+			}
+		}
+		dirs[filepath.Dir(file)] = true
+	}
 
-	pkgs, err := packages.Load(cfg, patterns...)
+	// Find packages whose directory contains a changed file
+	list := make([]*packages.Package, 0, len(current))
+	for _, pkg := range current {
+		list = append(list, pkg)
+	}
+	all := withDependencies(list)
+	affected := make(map[string]bool)
+	known := make(map[string]bool)
+	for _, pkg := range all {
+		dir := packageDir(pkg)
+		if dir == "" {
+			continue
+		}
+		known[dir] = true
+		if dirs[dir] {
+			affected[pkg.PkgPath] = true
+		}
+	}
+
+	// Add reverse dependencies of the affected packages
+	importers := make(map[string][]string)
+	for _, pkg := range all {
+		for _, imp := range pkg.Imports {
+			importers[imp.PkgPath] = append(importers[imp.PkgPath], pkg.PkgPath)
+		}
+	}
+	queue := make([]string, 0, len(affected))
+	for path := range affected {
+		queue = append(queue, path)
+	}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		for _, importer := range importers[path] {
+			if !affected[importer] {
+				affected[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+
+	// In lazy mode, affected packages are released and type-checked again when next requested
+	if p.opts.Lazy {
+		return p.relist(current, affected, dirs)
+	}
+
+	// Only the packages under the root directory are reloaded, dependencies are loaded with them
+	reload := make(map[string]bool, len(affected))
+	for path := range affected {
+		if _, ok := current[path]; ok {
+			reload[path] = true
+		}
+	}
+	// Directories without a loaded package may contain a new package
+	var newDirs []string
+	for dir := range dirs {
+		if known[dir] || !p.contains(dir) {
+			continue
+		}
+		rel, _ := filepath.Rel(p.rootDir, dir)
+		newDirs = append(newDirs, "./"+filepath.ToSlash(rel))
+	}
+	if len(reload) == 0 && len(newDirs) == 0 {
+		return nil
+	}
+
+	patterns := append([]string(nil), newDirs...)
+	for path := range reload {
+		patterns = append(patterns, path)
+	}
+	pkgs, err := packages.Load(loadConfig(p.rootDir), patterns...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load packages: %w", err)
+		return fmt.Errorf("failed to reload packages: %w", err)
 	}
 
-	parser := &Parser{
-		pkgs: make(map[string]*packages.Package),
+	next := make(map[string]*packages.Package, len(current))
+	for path, pkg := range current {
+		if !reload[path] {
+			next[path] = pkg
+		}
+	}
+	for _, pkg := range pkgs {
+		// Packages whose files were all removed come back without any files
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+		next[pkg.PkgPath] = pkg
 	}
 
-	// Store packages in the map
+	p.setPackages(next, true)
+
+	return nil
+}
+
+// contains reports whether path is the root directory or under it.
+func (p *Parser) contains(path string) bool {
+	rel, err := filepath.Rel(p.rootDir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// reloadAll reloads every package under the root directory.
+// The caller must hold p.update.
+func (p *Parser) reloadAll() error {
+	if p.opts.Lazy {
+		return p.relist(nil, nil, nil)
+	}
+
+	pkgs, err := packages.Load(loadConfig(p.rootDir), "./...")
+	if err != nil {
+		return fmt.Errorf("failed to reload packages: %w", err)
+	}
+
+	next := make(map[string]*packages.Package, len(pkgs))
 	for _, pkg := range pkgs {
-		parser.pkgs[pkg.PkgPath] = pkg
+		next[pkg.PkgPath] = pkg
 	}
 
-	return parser, nil
+	p.setPackages(next, true)
+
+	return nil
+}
+
+// setPackages replaces the package map.
+// Comment and example indexes are kept for the packages that were not reloaded, and comment
+// indexes built for the others.
+// reloaded reports whether source files were reloaded, which also discards the caches that
+// load every package under the root directory themselves.
+func (p *Parser) setPackages(pkgs map[string]*packages.Package, reloaded bool) {
+	p.mu.RLock()
+	current := p.comments
+	keep := make([]*packages.Package, 0, len(pkgs)+len(p.extra))
+	for _, pkg := range pkgs {
+		keep = append(keep, pkg)
+	}
+	for _, pkg := range p.extra {
+		keep = append(keep, pkg)
+	}
+	p.mu.RUnlock()
+	comments := indexComments(current, keep)
+	reachable := withDependencies(keep)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pkgs = pkgs
+	p.generation++
+	p.docs = nil
+	p.deps = nil
+	p.comments = comments
+	// Test files are parsed into the FileSet of their package, so examples are only built again
+	// for the packages that were reloaded
+	examples := make(map[*packages.Package]exampleIndex, len(p.examples))
+	for _, pkg := range reachable {
+		if index, ok := p.examples[pkg]; ok {
+			examples[pkg] = index
+		}
+	}
+	p.examples = examples
+	for path := range p.lazy.used {
+		if _, ok := pkgs[path]; !ok {
+			delete(p.lazy.used, path)
+		}
+	}
+	for pkg := range p.indexes {
+		if pkgs[pkg.PkgPath] != pkg {
+			delete(p.indexes, pkg)
+		}
+	}
+	if reloaded {
+		p.sources++
+		p.calls = nil
+		p.tests = nil
+	}
+}
+
+// setBaseDir sets the directory file paths are reported relative to.
+// Indexes built with the previous directory are discarded.
+func (p *Parser) setBaseDir(dir string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.baseDir == dir {
+		return
+	}
+	p.baseDir = dir
+	p.generation++
+	p.docs = nil
+}
+
+// packageDir returns the directory containing the package's source files.
+func packageDir(pkg *packages.Package) string {
+	if len(pkg.GoFiles) == 0 {
+		return ""
+	}
+	return filepath.Dir(pkg.GoFiles[0])
 }
 
 // GetAllPackages returns all loaded packages
 func (p *Parser) GetAllPackages() []*packages.Package {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	result := make([]*packages.Package, 0, len(p.pkgs))
 	for _, pkg := range p.pkgs {
 		result = append(result, pkg)
@@ -60,38 +378,40 @@ func (p *Parser) GetAllPackages() []*packages.Package {
 	return result
 }
 
-// GetPackage returns a package by its package path.
-// Returns an error if the package is not found.
-func (p *Parser) GetPackage(pkgPath string) (*packages.Package, error) {
-	pkg, ok := p.pkgs[pkgPath]
-	if !ok {
-		return nil, fmt.Errorf("package not found: %s", pkgPath)
-	}
-	return pkg, nil
-}
-
 // StructInfo represents information about a struct
 type StructInfo struct {
-	Name    string   // Struct name
-	Comment string   // Struct comment
-	Fields  []Field  // List of fields
-	Methods []Method // List of methods
+	Name           string          // Struct name
+	Comment        string          // Struct comment
+	Fields         []Field         // List of fields
+	Methods        []Method        // List of methods
+	Examples       []Example       // Struct examples
+	Layout         *StructLayout   // Memory layout of the struct, nil for generic structs
+	Declaration    string          // Go declaration of the struct
+	TypeParams     []TypeParam     // Type parameters of a generic struct
+	Instantiations []Instantiation // Instantiations of a generic struct
 }
 
 // Field represents information about a struct field
 type Field struct {
-	Name       string // Field name
-	Type       string // Field type
-	Comment    string // Field comment
-	IsExported bool   // Whether the field is exported
+	Name       string       // Field name
+	Type       string       // Field type
+	Comment    string       // Field comment
+	IsExported bool         // Whether the field is exported
+	Embedded   bool         // Whether the field is embedded
+	Tag        string       // Raw struct tag
+	Tags       []StructTag  // Key-value pairs of the struct tag
+	Layout     *FieldLayout // Memory layout of the field, nil for generic structs
 }
 
 // Method represents information about a struct method
 type Method struct {
-	Name      string    // Method name
-	Signature string    // Method signature
-	Comment   string    // Method comment
-	Examples  []Example // Method examples
+	Name            string      // Method name
+	Signature       string      // Method declaration
+	Comment         string      // Method comment
+	PromotedFrom    string      // Embedded type the method is promoted from, empty for declared methods
+	PointerReceiver bool        // Whether the method is only in the method set of the pointer type
+	Examples        []Example   // Method examples
+	TypeParams      []TypeParam // Type parameters of a generic receiver type
 }
 
 // GetStructInfo returns information about a struct in the specified package
@@ -120,50 +440,65 @@ func (p *Parser) GetStructInfo(pkgPath, structName string) (*StructInfo, error)
 	}
 
 	// Build struct information
+	qf := qualifier(pkg.Types)
 	info := &StructInfo{
-		Name:    structName,
-		Comment: GetComment(pkg, obj),
-		Fields:  make([]Field, 0, structType.NumFields()),
-		Methods: make([]Method, 0),
+		Name:           structName,
+		Comment:        p.GetComment(pkg, obj),
+		Fields:         make([]Field, 0, structType.NumFields()),
+		Examples:       p.GetExamples(pkg, structName),
+		Declaration:    declaration(pkg, obj),
+		TypeParams:     getTypeParams(named.TypeParams(), qf),
+		Instantiations: make([]Instantiation, 0),
+	}
+	if named.TypeParams().Len() > 0 {
+		info.Instantiations = p.getInstantiations(obj)
 	}
 
 	// Get field information
+	// The layout of a generic struct depends on its type arguments
+	var fieldLayouts []FieldLayout
+	if named.TypeParams().Len() == 0 {
+		info.Layout, fieldLayouts = structLayout(pkg, structType, p.goarch())
+	}
 	for i := 0; i < structType.NumFields(); i++ {
 		field := structType.Field(i)
-		info.Fields = append(info.Fields, Field{
+		f := Field{
 			Name:       field.Name(),
-			Type:       field.Type().String(),
-			Comment:    GetComment(pkg, field),
+			Type:       types.TypeString(field.Type(), qf),
+			Comment:    p.GetComment(pkg, field),
 			IsExported: field.Exported(),
-		})
+			Embedded:   field.Embedded(),
+			Tag:        structType.Tag(i),
+			Tags:       parseStructTag(structType.Tag(i)),
+		}
+		if fieldLayouts != nil {
+			f.Layout = &fieldLayouts[i]
+		}
+		info.Fields = append(info.Fields, f)
 	}
 
-	// Get method information
-	for i := 0; i < named.NumMethods(); i++ {
-		method := named.Method(i)
-		info.Methods = append(info.Methods, Method{
-			Name:      method.Name(),
-			Signature: method.Type().String(),
-			Comment:   GetComment(pkg, method),
-		})
-	}
+	// Get the method set, including methods promoted from embedded fields
+	info.Methods = p.methodSet(pkg, named)
 
 	return info, nil
 }
 
 // FuncInfo represents information about a function
 type FuncInfo struct {
-	Name      string    // Function name
-	Signature string    // Function signature
-	Comment   string    // Function comment
-	Examples  []Example // Function examples
+	Name           string          // Function name
+	Signature      string          // Function declaration
+	Comment        string          // Function comment
+	Examples       []Example       // Function examples
+	TypeParams     []TypeParam     // Type parameters of a generic function
+	Instantiations []Instantiation // Instantiations of a generic function
 }
 
 // Example represents an example for a function
 type Example struct {
-	Name   string // Example name
-	Code   string // Example code
-	Output string // Example output
+	Name      string // Example function name
+	Code      string // Example code
+	Output    string // Expected output
+	Unordered bool   // Whether the output lines may appear in any order
 }
 
 // GetFuncInfo returns information about a function in the specified package
@@ -187,15 +522,21 @@ func (p *Parser) GetFuncInfo(pkgPath, funcName string) (*FuncInfo, error) {
 	}
 
 	// Build function information
+	sig := fn.Type().(*types.Signature)
 	info := &FuncInfo{
-		Name:      funcName,
-		Signature: fn.Type().String(),
-		Comment:   GetComment(pkg, obj),
-		Examples:  make([]Example, 0),
+		Name:           funcName,
+		Signature:      declaration(pkg, obj),
+		Comment:        p.GetComment(pkg, obj),
+		Examples:       make([]Example, 0),
+		TypeParams:     getTypeParams(sig.TypeParams(), qualifier(pkg.Types)),
+		Instantiations: make([]Instantiation, 0),
+	}
+	if sig.TypeParams().Len() > 0 {
+		info.Instantiations = p.getInstantiations(obj)
 	}
 
 	// Get examples
-	info.Examples = getExamples(pkg, funcName)
+	info.Examples = p.GetExamples(pkg, funcName)
 
 	return info, nil
 }
@@ -220,47 +561,48 @@ func (p *Parser) GetMethodInfo(pkgPath, structName, methodName string) (*Method,
 		return nil, fmt.Errorf("not a struct: %s in package %s", structName, pkgPath)
 	}
 
-	// Find the method
-	var method *types.Func
-	for i := 0; i < named.NumMethods(); i++ {
-		m := named.Method(i)
-		if m.Name() == methodName {
-			method = m
-			break
-		}
-	}
-
-	if method == nil {
+	// Find the method, including the methods promoted from embedded fields,
+	// as in the method set of the struct doc
+	method, ok := lookupMethod(named, pkg.Types, methodName)
+	if !ok {
 		return nil, fmt.Errorf("method not found: %s.%s in package %s", structName, methodName, pkgPath)
 	}
 
 	// Build method information
+	declPkg := p.packageOf(pkg, method.fn)
 	info := &Method{
-		Name:      methodName,
-		Signature: method.Type().String(),
-		Comment:   GetComment(pkg, method),
-		Examples:  make([]Example, 0),
+		Name:            methodName,
+		Signature:       declaration(declPkg, method.fn),
+		Comment:         p.GetComment(declPkg, method.fn),
+		PointerReceiver: types.NewMethodSet(named).Lookup(method.fn.Pkg(), methodName) == nil,
+		Examples:        make([]Example, 0),
+		TypeParams:      getTypeParams(method.recv.TypeParams(), qualifier(pkg.Types)),
+	}
+	if method.recv != named {
+		info.PromotedFrom = types.TypeString(method.recv, qualifier(pkg.Types))
 	}
 
 	// Get examples
-	info.Examples = getExamples(pkg, methodName)
+	info.Examples = p.GetExamples(pkg, structName+"."+methodName)
 
 	return info, nil
 }
 
 // ConstInfo represents information about a constant
 type ConstInfo struct {
-	Name    string // Constant name
-	Type    string // Constant type
-	Value   string // Constant value
-	Comment string // Constant comment
+	Name        string // Constant name
+	Type        string // Constant type
+	Value       string // Constant value
+	Comment     string // Constant comment
+	Declaration string // Go declaration of the constant, including its declaration group
 }
 
 // VarInfo represents information about a variable
 type VarInfo struct {
-	Name    string // Variable name
-	Type    string // Variable type
-	Comment string // Variable comment
+	Name        string // Variable name
+	Type        string // Variable type
+	Comment     string // Variable comment
+	Declaration string // Go declaration of the variable, including its declaration group
 }
 
 // GetConstAndVarInfo returns information about constants and variables in the specified package
@@ -272,6 +614,7 @@ func (p *Parser) GetConstAndVarInfo(pkgPath string) ([]ConstInfo, []VarInfo, err
 
 	// Get type information from the package
 	scope := pkg.Types.Scope()
+	qf := qualifier(pkg.Types)
 
 	// Get constant information
 	constants := make([]ConstInfo, 0)
@@ -284,10 +627,11 @@ func (p *Parser) GetConstAndVarInfo(pkgPath string) ([]ConstInfo, []VarInfo, err
 		// Check if it's a constant
 		if constObj, ok := obj.(*types.Const); ok {
 			constants = append(constants, ConstInfo{
-				Name:    name,
-				Type:    constObj.Type().String(),
-				Value:   constObj.Val().String(),
-				Comment: GetComment(pkg, obj),
+				Name:        name,
+				Type:        types.TypeString(constObj.Type(), qf),
+				Value:       constObj.Val().String(),
+				Comment:     p.GetComment(pkg, obj),
+				Declaration: declaration(pkg, obj),
 			})
 		}
 	}
@@ -303,9 +647,10 @@ func (p *Parser) GetConstAndVarInfo(pkgPath string) ([]ConstInfo, []VarInfo, err
 		// Check if it's a variable
 		if varObj, ok := obj.(*types.Var); ok {
 			variables = append(variables, VarInfo{
-				Name:    name,
-				Type:    varObj.Type().String(),
-				Comment: GetComment(pkg, obj),
+				Name:        name,
+				Type:        types.TypeString(varObj.Type(), qf),
+				Comment:     p.GetComment(pkg, obj),
+				Declaration: declaration(pkg, obj),
 			})
 		}
 	}
@@ -313,133 +658,27 @@ func (p *Parser) GetConstAndVarInfo(pkgPath string) ([]ConstInfo, []VarInfo, err
 	return constants, variables, nil
 }
 
-// getExamples returns examples for a function
-func getExamples(pkg *packages.Package, funcName string) []Example {
-	var examples []Example
-
-	// Search AST nodes in the package
-	for _, file := range pkg.Syntax {
-		ast.Inspect(file, func(n ast.Node) bool {
-			// Look for function declarations
-			funcDecl, ok := n.(*ast.FuncDecl)
-			if !ok {
-				return true
-			}
-
-			// Check if it's an example function
-			if !strings.HasPrefix(funcDecl.Name.Name, "Example") {
-				return true
-			}
-
-			// Check if it's an example for the target function
-			if !strings.HasSuffix(funcDecl.Name.Name, funcName) {
-				return true
-			}
-
-			// Get example information
-			example := Example{
-				Name: funcDecl.Name.Name,
-			}
-
-			// Get example code
-			if funcDecl.Body != nil {
-				example.Code = getNodeString(pkg.Fset, funcDecl.Body)
-			}
-
-			// Get example output
-			if funcDecl.Doc != nil {
-				for _, comment := range funcDecl.Doc.List {
-					if strings.HasPrefix(comment.Text, "// Output:") {
-						example.Output = strings.TrimPrefix(comment.Text, "// Output:")
-						example.Output = strings.TrimSpace(example.Output)
-					}
-				}
-			}
-
-			examples = append(examples, example)
-			return true
-		})
-	}
-
-	return examples
-}
-
-// getNodeString returns the string representation of a node
-func getNodeString(fset *token.FileSet, node ast.Node) string {
-	var buf strings.Builder
-	if err := format.Node(&buf, fset, node); err != nil {
-		return ""
-	}
-	return buf.String()
-}
-
-// GetComment returns the comment for an object
-func GetComment(pkg *packages.Package, obj types.Object) string {
-	var comment string
-
-	// Search AST nodes in the package
-	for _, file := range pkg.Syntax {
-		ast.Inspect(file, func(n ast.Node) bool {
-			switch node := n.(type) {
-			case *ast.TypeSpec:
-				// For type declarations
-				if node.Name.Name == obj.Name() {
-					if node.Doc != nil {
-						comment = node.Doc.Text()
-					}
-					return false
-				}
-			case *ast.Field:
-				// For fields
-				for _, name := range node.Names {
-					if name.Name == obj.Name() {
-						if node.Doc != nil {
-							comment = node.Doc.Text()
-						} else if node.Comment != nil {
-							comment = node.Comment.Text()
-						}
-						return false
-					}
-				}
-			case *ast.FuncDecl:
-				// For methods
-				if node.Name.Name == obj.Name() {
-					if node.Doc != nil {
-						comment = node.Doc.Text()
-					}
-					return false
-				}
-			case *ast.ValueSpec:
-				// For constants and variables
-				for _, name := range node.Names {
-					if name.Name == obj.Name() {
-						if node.Doc != nil {
-							comment = node.Doc.Text()
-						} else if node.Comment != nil {
-							comment = node.Comment.Text()
-						}
-						return false
-					}
-				}
-			}
-			return true
-		})
-	}
-
-	// Trim whitespace from the comment
-	return strings.TrimSpace(comment)
-}
-
 // GetPackageComment returns the package comment.
 // Package comments are typically comment blocks before the package declaration.
+// Packages loaded without syntax, such as the listed packages of lazy mode, have their
+// package clauses parsed.
 func GetPackageComment(pkg *packages.Package) string {
-	if pkg == nil || len(pkg.Syntax) == 0 {
+	if pkg == nil {
 		return ""
 	}
+	files := pkg.Syntax
+	if len(files) == 0 {
+		fset := token.NewFileSet()
+		for _, name := range pkg.GoFiles {
+			if file, err := parser.ParseFile(fset, name, nil, parser.PackageClauseOnly|parser.ParseComments); err == nil {
+				files = append(files, file)
+			}
+		}
+	}
 
 	var comment string
 	// Search for package comments in each file
-	for _, file := range pkg.Syntax {
+	for _, file := range files {
 		if file.Doc != nil && file.Doc.Text() != "" {
 			// If multiple files have package comments, use the first non-empty comment
 			comment = file.Doc.Text()
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeModule creates a module named example.com/m in a temporary directory
+// with the given files and returns the directory.
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/m\n\ngo 1.24\n"})
+	writeFiles(t, dir, files)
+	return dir
+}
+
+// writeFiles writes the given files relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("os.MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+}
+
+func TestParser_Reload(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"a/a.go": "package a\n\nimport \"fmt\"\n\ntype Old struct{}\n\nvar _ fmt.Stringer\n",
+		"b/b.go": "package b\n\nimport \"example.com/m/a\"\n\nvar V a.Old\n",
+		"c/c.go": "package c\n\nimport \"fmt\"\n\nvar C = fmt.Sprint(1)\n",
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	oldB, err := p.GetPackage("example.com/m/b")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	oldC, err := p.GetPackage("example.com/m/c")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"a/a.go": "package a\n\nimport \"fmt\"\n\n// Old has a name.\ntype Old struct{ Name string }\n\nvar _ fmt.Stringer\n",
+		"d/d.go": "package d\n",
+	})
+	if err := p.Reload([]string{filepath.Join(dir, "a/a.go"), filepath.Join(dir, "d/d.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	info, err := p.GetStructInfo("example.com/m/a", "Old")
+	if err != nil {
+		t.Fatalf("GetStructInfo() error = %v", err)
+	}
+	if len(info.Fields) != 1 {
+		t.Errorf("len(Fields) = %d, want 1", len(info.Fields))
+	}
+	if want := "Old has a name."; info.Comment != want {
+		t.Errorf("Comment = %q, want %q", info.Comment, want)
+	}
+
+	// Reverse dependencies are reloaded, unrelated packages are kept even if they share a dependency
+	if newB, _ := p.GetPackage("example.com/m/b"); newB == oldB {
+		t.Error("reverse dependency example.com/m/b was not reloaded")
+	}
+	if newC, _ := p.GetPackage("example.com/m/c"); newC != oldC {
+		t.Error("unrelated package example.com/m/c was reloaded")
+	}
+	if _, err := p.GetPackage("example.com/m/d"); err != nil {
+		t.Errorf("new package example.com/m/d was not loaded: %v", err)
+	}
+
+	// Removing every file of a package unloads it
+	if err := os.Remove(filepath.Join(dir, "c/c.go")); err != nil {
+		t.Fatalf("os.Remove() error = %v", err)
+	}
+	if err := p.Reload([]string{filepath.Join(dir, "c/c.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if _, err := p.GetPackage("example.com/m/c"); err == nil {
+		t.Error("removed package example.com/m/c is still loaded")
+	}
+}
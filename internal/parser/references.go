@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/objectpath"
+)
+
+// Reference represents a use of a symbol
+type Reference struct {
+	PkgPath   string // Path of the package containing the reference
+	File      string // File containing the reference, relative to the root directory
+	Line      int    // Line of the reference
+	Column    int    // Column of the reference, in bytes
+	Enclosing string // Function or method containing the reference, empty at package level
+}
+
+// ReferencesInfo represents the references to a symbol
+type ReferencesInfo struct {
+	Name       string      // Symbol name, qualified with the type name for methods and fields
+	Kind       string      // Symbol kind
+	PkgPath    string      // Path of the package declaring the symbol
+	Position   string      // Source position of the declaration
+	References []Reference // References sorted by file and position
+}
+
+// testPackages holds the packages under the root directory loaded with their test files.
+type testPackages struct {
+	generation uint64              // Package map generation the packages were loaded for
+	scope      string              // Package whose importers were loaded in lazy mode, empty if every package was loaded
+	pkgs       []*packages.Package // Packages and their test variants
+}
+
+// FindReferences returns the references to the specified package-level symbol, method or field
+// in the packages under the root directory, including their test files.
+// symbol is an identifier, or "Type.Name" for methods and fields.
+func (p *Parser) FindReferences(pkgPath, symbol string) (*ReferencesInfo, error) {
+	pkg, err := p.GetPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *symbolCandidate
+	forEachSymbol(pkg, func(c symbolCandidate) {
+		if found == nil && c.name == symbol {
+			found = &c
+		}
+	})
+	if found == nil {
+		return nil, fmt.Errorf("symbol not found: %s in package %s", symbol, pkgPath)
+	}
+	// Packages loaded separately do not share objects, so the symbol is matched by its path
+	target, err := objectpath.For(found.obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate %s in package %s: %w", symbol, pkgPath, err)
+	}
+
+	// Non-test files are part of both a package and its test variant
+	seen := make(map[token.Position]bool)
+	refs := make([]Reference, 0)
+	for _, refPkg := range p.testPackages(pkgPath) {
+		var enc objectpath.Encoder
+		for _, file := range refPkg.Syntax {
+			for _, ident := range fileUses(refPkg, file) {
+				obj := origin(refPkg.TypesInfo.Uses[ident])
+				if obj.Name() != found.obj.Name() || obj.Pkg() == nil || obj.Pkg().Path() != pkg.Types.Path() {
+					continue
+				}
+				if path, err := enc.For(obj); err != nil || path != target {
+					continue
+				}
+				position := refPkg.Fset.Position(ident.Pos())
+				if seen[position] {
+					continue
+				}
+				seen[position] = true
+				refs = append(refs, Reference{
+					PkgPath:   refPkg.PkgPath,
+					File:      p.relPath(position.Filename),
+					Line:      position.Line,
+					Column:    position.Column,
+					Enclosing: enclosingFunc(file, ident.Pos()),
+				})
+			}
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return &ReferencesInfo{
+		Name:       found.name,
+		Kind:       found.kind,
+		PkgPath:    pkg.PkgPath,
+		Position:   p.position(pkg.Fset, found.obj.Pos()),
+		References: refs,
+	}, nil
+}
+
+// fileUses returns the identifiers of file that refer to an object, in source order.
+func fileUses(pkg *packages.Package, file *ast.File) []*ast.Ident {
+	var idents []*ast.Ident
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pkg.TypesInfo.Uses[ident] != nil {
+			idents = append(idents, ident)
+		}
+		return true
+	})
+	return idents
+}
+
+// enclosingFunc returns the name of the function or method declared in file that contains pos,
+// qualified with the receiver type name for methods.
+func enclosingFunc(file *ast.File, pos token.Pos) string {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || pos < fn.Pos() || pos >= fn.End() {
+			continue
+		}
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			if recv := embeddedIdent(fn.Recv.List[0].Type); recv != nil {
+				return recv.Name + "." + fn.Name.Name
+			}
+		}
+		return fn.Name.Name
+	}
+	return ""
+}
+
+// testPackages returns the packages under the root directory with their test variants,
+// loading them on first use for each package map generation. In lazy mode, only the packages
+// that can refer to the package at pkgPath are loaded: the package and its importers.
+// The loaded packages are returned if the test files cannot be loaded.
+func (p *Parser) testPackages(pkgPath string) []*packages.Package {
+	var scope string
+	if p.opts.Lazy {
+		scope = pkgPath
+	}
+
+	p.mu.RLock()
+	tests, generation := p.tests, p.sources
+	p.mu.RUnlock()
+	if tests != nil && tests.generation == generation && tests.scope == scope {
+		return tests.pkgs
+	}
+
+	patterns := []string{"./..."}
+	if scope != "" {
+		paths, err := p.testImporters(scope)
+		if err != nil {
+			return p.GetAllPackages()
+		}
+		patterns = paths
+	}
+	cfg := loadConfig(p.rootDir)
+	cfg.Tests = true
+	loaded, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return p.GetAllPackages()
+	}
+	pkgs := make([]*packages.Package, 0, len(loaded))
+	for _, pkg := range loaded {
+		// Generated test main packages only refer to the test functions
+		if pkg.TypesInfo == nil || strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		pkgs = append(pkgs, pkg)
+	}
+
+	p.mu.Lock()
+	// Keep the packages only if no reload happened while loading them
+	if p.sources == generation {
+		p.tests = &testPackages{generation: generation, scope: scope, pkgs: pkgs}
+	}
+	p.mu.Unlock()
+
+	return pkgs
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_FindReferences(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"store/store.go": `package store
+
+// Store holds items.
+type Store struct {
+	Items []string
+}
+
+// Add adds an item.
+func (s *Store) Add(item string) {
+	s.Items = append(s.Items, item)
+}
+
+// Default is the default store.
+var Default = New()
+
+// New creates a store.
+func New() *Store { return &Store{} }
+`,
+		"store/store_test.go": `package store_test
+
+import (
+	"testing"
+
+	"example.com/m/store"
+)
+
+func TestAdd(t *testing.T) {
+	s := store.New()
+	s.Add("a")
+	_ = s.Items
+}
+`,
+		"app/app.go": `package app
+
+import "example.com/m/store"
+
+// Run adds an item to the default store.
+func Run() {
+	store.Default.Add("x")
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		symbol string
+		want   []Reference
+	}{
+		"function": {
+			symbol: "New",
+			want: []Reference{
+				{PkgPath: "example.com/m/store", File: "store/store.go", Line: 14, Column: 15},
+				{PkgPath: "example.com/m/store_test", File: "store/store_test.go", Line: 10, Column: 13, Enclosing: "TestAdd"},
+			},
+		},
+		"method": {
+			symbol: "Store.Add",
+			want: []Reference{
+				{PkgPath: "example.com/m/app", File: "app/app.go", Line: 7, Column: 16, Enclosing: "Run"},
+				{PkgPath: "example.com/m/store_test", File: "store/store_test.go", Line: 11, Column: 4, Enclosing: "TestAdd"},
+			},
+		},
+		"field": {
+			symbol: "Store.Items",
+			want: []Reference{
+				{PkgPath: "example.com/m/store", File: "store/store.go", Line: 10, Column: 4, Enclosing: "Store.Add"},
+				{PkgPath: "example.com/m/store", File: "store/store.go", Line: 10, Column: 21, Enclosing: "Store.Add"},
+				{PkgPath: "example.com/m/store_test", File: "store/store_test.go", Line: 12, Column: 8, Enclosing: "TestAdd"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := p.FindReferences("example.com/m/store", tt.symbol)
+			if err != nil {
+				t.Fatalf("FindReferences() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.References, tt.want) {
+				t.Errorf("References = %+v, want %+v", got.References, tt.want)
+			}
+		})
+	}
+
+	if _, err := p.FindReferences("example.com/m/store", "Missing"); err == nil {
+		t.Error("FindReferences() of a missing symbol succeeded, want an error")
+	}
+}
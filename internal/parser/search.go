@@ -0,0 +1,206 @@
+package parser
+
+import (
+	"go/doc"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Symbol kinds returned by SearchSymbols
+const (
+	SymbolKindFunc      = "func"
+	SymbolKindMethod    = "method"
+	SymbolKindStruct    = "struct"
+	SymbolKindInterface = "interface"
+	SymbolKindType      = "type"
+	SymbolKindConst     = "const"
+	SymbolKindVar       = "var"
+	SymbolKindField     = "field"
+)
+
+// Match scores by match quality
+const (
+	scoreExact     = 100
+	scorePrefix    = 75
+	scoreSubstring = 50
+	scoreFuzzy     = 25
+	scoreExported  = 10
+)
+
+// Symbol represents a symbol found by SearchSymbols
+type Symbol struct {
+	Name      string // Symbol name, qualified with the type name for methods and fields
+	Kind      string // Symbol kind
+	PkgPath   string // Package path of the symbol
+	Signature string // One-line signature, as go doc -short shows it
+	Summary   string // First sentence of the symbol comment
+	Position  string // Source position of the declaration
+	Exported  bool   // Whether the symbol is exported
+	Score     int    // Match score, higher is better
+}
+
+// symbolCandidate is a symbol declared in a package.
+type symbolCandidate struct {
+	pkg  *packages.Package
+	obj  types.Object
+	name string
+	kind string
+}
+
+// symbolMatch is an indexed symbol matching a query, before ranking.
+type symbolMatch struct {
+	pkgPath string
+	symbol  *indexSymbol
+	score   int
+}
+
+// SearchSymbols searches package-level identifiers, methods and fields of the loaded packages.
+// Names are matched case-insensitively as an exact match, a prefix, a substring or a fuzzy
+// subsequence, and the results are ranked by match quality and exportedness.
+// Methods and fields match by their own name, or by "Type.Name" if the query contains a dot.
+// If kind is not empty, only symbols of that kind are returned. At most limit symbols are returned.
+// In lazy mode, the packages that are not loaded are searched through the index cache.
+func (p *Parser) SearchSymbols(query, kind string, limit int) []Symbol {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []Symbol{}
+	}
+
+	var matches []symbolMatch
+	for _, idx := range p.packageIndexes() {
+		for i := range idx.Symbols {
+			s := &idx.Symbols[i]
+			if kind != "" && s.Kind != kind {
+				continue
+			}
+			// Methods and fields are matched by their own name unless the query is qualified
+			name := s.Name
+			if i := strings.LastIndex(name, "."); i >= 0 && !strings.Contains(query, ".") {
+				name = name[i+1:]
+			}
+			score := matchScore(query, name)
+			if score == 0 {
+				continue
+			}
+			if s.Exported {
+				score += scoreExported
+			}
+			matches = append(matches, symbolMatch{pkgPath: idx.PkgPath, symbol: s, score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if len(a.symbol.Name) != len(b.symbol.Name) {
+			return len(a.symbol.Name) < len(b.symbol.Name)
+		}
+		if a.pkgPath != b.pkgPath {
+			return a.pkgPath < b.pkgPath
+		}
+		return a.symbol.Name < b.symbol.Name
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	// Summaries are only extracted for the returned symbols
+	symbols := make([]Symbol, 0, len(matches))
+	for _, m := range matches {
+		symbols = append(symbols, Symbol{
+			Name:      m.symbol.Name,
+			Kind:      m.symbol.Kind,
+			PkgPath:   m.pkgPath,
+			Signature: m.symbol.Signature,
+			Summary:   summary(m.symbol.Comment),
+			Position:  p.indexPosition(m.symbol),
+			Exported:  m.symbol.Exported,
+			Score:     m.score,
+		})
+	}
+	return symbols
+}
+
+// forEachSymbol calls fn for every package-level identifier, method and field declared in pkg.
+func forEachSymbol(pkg *packages.Package, fn func(c symbolCandidate)) {
+	if pkg.Types == nil {
+		return
+	}
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		switch obj := obj.(type) {
+		case *types.Func:
+			fn(symbolCandidate{pkg: pkg, obj: obj, name: name, kind: SymbolKindFunc})
+		case *types.Const:
+			fn(symbolCandidate{pkg: pkg, obj: obj, name: name, kind: SymbolKindConst})
+		case *types.Var:
+			fn(symbolCandidate{pkg: pkg, obj: obj, name: name, kind: SymbolKindVar})
+		case *types.TypeName:
+			switch underlying := obj.Type().Underlying().(type) {
+			case *types.Struct:
+				fn(symbolCandidate{pkg: pkg, obj: obj, name: name, kind: SymbolKindStruct})
+				for i := 0; i < underlying.NumFields(); i++ {
+					field := underlying.Field(i)
+					fn(symbolCandidate{pkg: pkg, obj: field, name: name + "." + field.Name(), kind: SymbolKindField})
+				}
+			case *types.Interface:
+				fn(symbolCandidate{pkg: pkg, obj: obj, name: name, kind: SymbolKindInterface})
+				for i := 0; i < underlying.NumExplicitMethods(); i++ {
+					method := underlying.ExplicitMethod(i)
+					fn(symbolCandidate{pkg: pkg, obj: method, name: name + "." + method.Name(), kind: SymbolKindMethod})
+				}
+			default:
+				fn(symbolCandidate{pkg: pkg, obj: obj, name: name, kind: SymbolKindType})
+			}
+			if named, ok := obj.Type().(*types.Named); ok && !obj.IsAlias() {
+				for i := 0; i < named.NumMethods(); i++ {
+					method := named.Method(i)
+					fn(symbolCandidate{pkg: pkg, obj: method, name: name + "." + method.Name(), kind: SymbolKindMethod})
+				}
+			}
+		}
+	}
+}
+
+// matchScore returns how well name matches query, or 0 if it does not match.
+func matchScore(query, name string) int {
+	if query == name {
+		return scoreExact + 1
+	}
+	q := strings.ToLower(query)
+	n := strings.ToLower(name)
+	switch {
+	case q == n:
+		return scoreExact
+	case strings.HasPrefix(n, q):
+		return scorePrefix
+	case strings.Contains(n, q):
+		return scoreSubstring
+	}
+
+	// Fuzzy match: query characters appear in order, penalized by the gaps between them
+	gaps := 0
+	j := 0
+	for i := 0; i < len(n) && j < len(q); i++ {
+		if n[i] == q[j] {
+			j++
+		} else if j > 0 {
+			gaps++
+		}
+	}
+	if j < len(q) {
+		return 0
+	}
+	return max(scoreFuzzy-gaps, 1)
+}
+
+// summary returns the first sentence of a comment.
+func summary(comment string) string {
+	return new(doc.Package).Synopsis(comment)
+}
@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_SearchSymbols(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"store/store.go": `package store
+
+// Store stores values. It is safe for concurrent use.
+type Store struct {
+	// StoreDir is the directory of the store.
+	StoreDir string
+	restore  bool
+}
+
+// NewStore creates a Store.
+func NewStore() *Store { return nil }
+
+// Close closes the store.
+func (s *Store) Close() error { return nil }
+
+const MaxSize = 10
+
+func storeHelper() {}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		query string
+		kind  string
+		limit int
+		want  []string
+	}{
+		"ranked by match quality and exportedness": {
+			query: "store",
+			want:  []string{"Store", "Store.StoreDir", "storeHelper", "NewStore", "Store.restore"},
+		},
+		"filtered by kind": {
+			query: "store",
+			kind:  SymbolKindField,
+			want:  []string{"Store.StoreDir", "Store.restore"},
+		},
+		"qualified method name": {
+			query: "Store.Close",
+			want:  []string{"Store.Close"},
+		},
+		"fuzzy match": {
+			query: "mxsz",
+			want:  []string{"MaxSize"},
+		},
+		"limited": {
+			query: "store",
+			limit: 2,
+			want:  []string{"Store", "Store.StoreDir"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			symbols := p.SearchSymbols(tt.query, tt.kind, tt.limit)
+			var got []string
+			for _, s := range symbols {
+				got = append(got, s.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchSymbols() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("summary", func(t *testing.T) {
+		t.Parallel()
+
+		symbols := p.SearchSymbols("NewStore", "", 1)
+		if len(symbols) != 1 {
+			t.Fatalf("len(SearchSymbols()) = %d, want 1", len(symbols))
+		}
+		want := Symbol{
+			Name:      "NewStore",
+			Kind:      SymbolKindFunc,
+			PkgPath:   "example.com/m/store",
+			Signature: "func NewStore() *Store",
+			Summary:   "NewStore creates a Store.",
+			Position:  "store/store.go:11",
+			Exported:  true,
+			Score:     scoreExact + 1 + scoreExported,
+		}
+		if symbols[0] != want {
+			t.Errorf("SearchSymbols() = %+v, want %+v", symbols[0], want)
+		}
+	})
+
+	t.Run("signatures", func(t *testing.T) {
+		t.Parallel()
+
+		want := map[string]string{
+			"Store":          "type Store struct{ ... }",
+			"Store.StoreDir": "StoreDir string",
+			"Store.Close":    "func (s *Store) Close() error",
+			"MaxSize":        "const MaxSize = 10",
+		}
+		for name, signature := range want {
+			symbols := p.SearchSymbols(name, "", 1)
+			if len(symbols) != 1 || symbols[0].Signature != signature {
+				t.Errorf("SearchSymbols(%q) = %+v, want signature %q", name, symbols, signature)
+			}
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// SourceInfo represents the source code of a declaration
+type SourceInfo struct {
+	Name      string // Symbol name, qualified with the type name for methods and fields
+	Kind      string // Symbol kind
+	PkgPath   string // Package path of the symbol
+	File      string // File containing the declaration
+	StartLine int    // First line of the returned source, 1-based
+	EndLine   int    // Last line of the returned source, inclusive
+	Source    string // Source text
+}
+
+// GetSourceInfo returns the source code of the declaration of a symbol in the specified package.
+// The symbol is a package-level identifier, or "Type.Name" for methods and fields. Constants and
+// variables are returned with their whole declaration block, and a type declared in a group on its own.
+// If includeComment is true, the doc comment is included. contextLines lines before and after the
+// declaration are included as well, and none if contextLines is negative.
+func (p *Parser) GetSourceInfo(pkgPath, symbol string, contextLines int, includeComment bool) (*SourceInfo, error) {
+	pkg, err := p.GetPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *symbolCandidate
+	forEachSymbol(pkg, func(c symbolCandidate) {
+		if found == nil && c.name == symbol {
+			found = &c
+		}
+	})
+	if found == nil {
+		return nil, fmt.Errorf("symbol not found: %s in package %s", symbol, pkgPath)
+	}
+
+	node, doc := sourceNode(pkg, found.obj)
+	if node == nil {
+		return nil, fmt.Errorf("source not found: %s in package %s", symbol, pkgPath)
+	}
+	start, end := node.Pos(), node.End()
+	if includeComment && doc != nil {
+		start = doc.Pos()
+	}
+
+	// Return whole lines so that indentation and trailing comments are kept
+	startPos, endPos := pkg.Fset.Position(start), pkg.Fset.Position(end)
+	content, err := os.ReadFile(startPos.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source file: %w", err)
+	}
+	// The positions are only valid in the file as it was loaded
+	if file := pkg.Fset.File(start); file == nil || file.Size() != len(content) {
+		return nil, fmt.Errorf("source file changed since it was loaded: %s", startPos.Filename)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if endPos.Line > len(lines) {
+		return nil, fmt.Errorf("source file changed since it was loaded: %s", startPos.Filename)
+	}
+	contextLines = max(contextLines, 0)
+	first := max(startPos.Line-contextLines, 1)
+	last := min(endPos.Line+contextLines, len(lines))
+
+	return &SourceInfo{
+		Name:      found.name,
+		Kind:      found.kind,
+		PkgPath:   pkg.PkgPath,
+		File:      p.relPath(startPos.Filename),
+		StartLine: first,
+		EndLine:   last,
+		Source:    strings.Join(lines[first-1:last], "\n"),
+	}, nil
+}
+
+// sourceNode returns the syntax node declaring obj and its doc comment.
+// Functions and methods are returned with their bodies, constants and variables with their
+// declaration block, and struct fields and interface methods as the field declaring them.
+func sourceNode(pkg *packages.Package, obj types.Object) (ast.Node, *ast.CommentGroup) {
+	_, decl, spec := findDecl(pkg, obj.Pos())
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d, d.Doc
+	case *ast.GenDecl:
+		if ts, ok := spec.(*ast.TypeSpec); ok && d.Lparen.IsValid() {
+			return ts, ts.Doc
+		}
+		return d, d.Doc
+	}
+
+	// Struct fields and interface methods are declared in a type declaration
+	file := fileOf(pkg, obj.Pos())
+	if file == nil {
+		return nil, nil
+	}
+	var field *ast.Field
+	ast.Inspect(file, func(n ast.Node) bool {
+		if field != nil || n == nil || obj.Pos() < n.Pos() || obj.Pos() >= n.End() {
+			return false
+		}
+		if f, ok := n.(*ast.Field); ok {
+			for _, name := range f.Names {
+				if name.Pos() == obj.Pos() {
+					field = f
+					return false
+				}
+			}
+			// Embedded fields have no names
+			if len(f.Names) == 0 && f.Type.Pos() <= obj.Pos() && obj.Pos() < f.Type.End() {
+				field = f
+				return false
+			}
+		}
+		return true
+	})
+	if field == nil {
+		return nil, nil
+	}
+	return field, field.Doc
+}
+
+// fileOf returns the syntax tree of the file containing pos.
+func fileOf(pkg *packages.Package, pos token.Pos) *ast.File {
+	for _, file := range pkg.Syntax {
+		if file.FileStart <= pos && pos < file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParser_GetSourceInfo(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"shape/shape.go": `package shape
+
+import "math"
+
+// Unit is a unit of length.
+const (
+	Meter = 1.0
+	Foot  = 0.3048
+)
+
+type (
+	// Circle is a circle.
+	Circle struct {
+		// Radius of the circle
+		Radius float64
+	}
+
+	// Shape is a shape.
+	Shape interface {
+		Area() float64
+	}
+)
+
+// Area returns the area.
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Double doubles x.
+func Double(x float64) float64 {
+	return 2 * x
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		symbol         string
+		contextLines   int
+		includeComment bool
+		want           SourceInfo
+	}{
+		"function": {
+			symbol: "Double",
+			want: SourceInfo{
+				Name: "Double", Kind: SymbolKindFunc, StartLine: 30, EndLine: 32,
+				Source: "func Double(x float64) float64 {\n\treturn 2 * x\n}",
+			},
+		},
+		"function with comment and context": {
+			symbol:         "Double",
+			contextLines:   1,
+			includeComment: true,
+			want: SourceInfo{
+				Name: "Double", Kind: SymbolKindFunc, StartLine: 28, EndLine: 32,
+				Source: "\n// Double doubles x.\nfunc Double(x float64) float64 {\n\treturn 2 * x\n}",
+			},
+		},
+		"negative context": {
+			symbol:       "Double",
+			contextLines: -3,
+			want: SourceInfo{
+				Name: "Double", Kind: SymbolKindFunc, StartLine: 30, EndLine: 32,
+				Source: "func Double(x float64) float64 {\n\treturn 2 * x\n}",
+			},
+		},
+		"method": {
+			symbol: "Circle.Area",
+			want: SourceInfo{
+				Name: "Circle.Area", Kind: SymbolKindMethod, StartLine: 25, EndLine: 27,
+				Source: "func (c Circle) Area() float64 {\n\treturn math.Pi * c.Radius * c.Radius\n}",
+			},
+		},
+		"type in a group": {
+			symbol:         "Circle",
+			includeComment: true,
+			want: SourceInfo{
+				Name: "Circle", Kind: SymbolKindStruct, StartLine: 12, EndLine: 16,
+				Source: "\t// Circle is a circle.\n\tCircle struct {\n\t\t// Radius of the circle\n\t\tRadius float64\n\t}",
+			},
+		},
+		"constant block": {
+			symbol: "Foot",
+			want: SourceInfo{
+				Name: "Foot", Kind: SymbolKindConst, StartLine: 6, EndLine: 9,
+				Source: "const (\n\tMeter = 1.0\n\tFoot  = 0.3048\n)",
+			},
+		},
+		"field": {
+			symbol:         "Circle.Radius",
+			includeComment: true,
+			want: SourceInfo{
+				Name: "Circle.Radius", Kind: SymbolKindField, StartLine: 14, EndLine: 15,
+				Source: "\t\t// Radius of the circle\n\t\tRadius float64",
+			},
+		},
+		"interface method": {
+			symbol: "Shape.Area",
+			want: SourceInfo{
+				Name: "Shape.Area", Kind: SymbolKindMethod, StartLine: 20, EndLine: 20,
+				Source: "\t\tArea() float64",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := p.GetSourceInfo("example.com/m/shape", tt.symbol, tt.contextLines, tt.includeComment)
+			if err != nil {
+				t.Fatalf("GetSourceInfo() error = %v", err)
+			}
+			tt.want.PkgPath = "example.com/m/shape"
+			tt.want.File = "shape/shape.go"
+			if *got != tt.want {
+				t.Errorf("GetSourceInfo() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := p.GetSourceInfo("example.com/m/shape", "Square", 0, false); err == nil {
+			t.Error("GetSourceInfo() error = nil, want error")
+		}
+	})
+}
+
+func TestParser_GetSourceInfoChangedFile(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"m.go": "package m\n\n// Double doubles x.\nfunc Double(x float64) float64 {\n\treturn 2 * x\n}\n",
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// The file shrinks after it was loaded, so the declaration is out of range
+	writeFiles(t, dir, map[string]string{"m.go": "package m\n"})
+	if _, err := p.GetSourceInfo("example.com/m", "Double", 0, false); err == nil {
+		t.Error("GetSourceInfo() error = nil, want error")
+	}
+}
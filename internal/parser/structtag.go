@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"go/types"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// StructTag represents a key:"value" pair of a struct tag
+type StructTag struct {
+	Key     string   // Tag key, such as json or db
+	Value   string   // Unquoted tag value
+	Name    string   // Value up to the first comma, usually the field name used by the key
+	Options []string // Comma-separated options following the name, such as omitempty
+}
+
+// FieldLayout represents the memory layout of a struct field
+type FieldLayout struct {
+	Offset int64 // Offset of the field in the struct, in bytes
+	Size   int64 // Size of the field, in bytes
+	Align  int64 // Alignment of the field, in bytes
+}
+
+// StructLayout represents the memory layout of a struct
+type StructLayout struct {
+	Arch  string // GOARCH the layout is computed for
+	Size  int64  // Size of the struct, in bytes
+	Align int64  // Alignment of the struct, in bytes
+}
+
+// parseStructTag splits a struct tag into its key:"value" pairs, following the conventions
+// of reflect.StructTag. Parsing stops at the first malformed pair.
+func parseStructTag(tag string) []StructTag {
+	var tags []StructTag
+	for tag != "" {
+		// Skip leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error.
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// Scan quoted string to find value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tag = tag[i+1:]
+
+		name, rest, _ := strings.Cut(value, ",")
+		entry := StructTag{Key: key, Value: value, Name: name}
+		if rest != "" {
+			entry.Options = strings.Split(rest, ",")
+		}
+		tags = append(tags, entry)
+	}
+	return tags
+}
+
+// structLayout returns the memory layout of st and of each of its fields, using the sizes of pkg,
+// which is loaded for arch.
+func structLayout(pkg *packages.Package, st *types.Struct, arch string) (*StructLayout, []FieldLayout) {
+	sizes := pkg.TypesSizes
+	if sizes == nil {
+		sizes = types.SizesFor("gc", arch)
+	}
+	if sizes == nil {
+		sizes = types.SizesFor("gc", "amd64")
+	}
+
+	fields := make([]*types.Var, st.NumFields())
+	for i := range fields {
+		fields[i] = st.Field(i)
+	}
+	offsets := sizes.Offsetsof(fields)
+
+	layouts := make([]FieldLayout, len(fields))
+	for i, field := range fields {
+		layouts[i] = FieldLayout{
+			Offset: offsets[i],
+			Size:   sizes.Sizeof(field.Type()),
+			Align:  sizes.Alignof(field.Type()),
+		}
+	}
+	return &StructLayout{Arch: arch, Size: sizes.Sizeof(st), Align: sizes.Alignof(st)}, layouts
+}
+
+// readGoarch returns the GOARCH packages under rootDir are loaded for, as reported by go env there
+// like the sizes of the packages, or the architecture of the running program if it fails.
+func readGoarch(rootDir string) string {
+	cmd := exec.Command("go", "env", "GOARCH")
+	cmd.Dir = rootDir
+	out, err := cmd.Output()
+	if arch := strings.TrimSpace(string(out)); err == nil && arch != "" {
+		return arch
+	}
+	return runtime.GOARCH
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseStructTag(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tag  string
+		want []StructTag
+	}{
+		"empty": {
+			tag:  "",
+			want: nil,
+		},
+		"name and options": {
+			tag: `json:"id,omitempty,string"`,
+			want: []StructTag{
+				{Key: "json", Value: "id,omitempty,string", Name: "id", Options: []string{"omitempty", "string"}},
+			},
+		},
+		"multiple keys": {
+			tag: `json:"name" db:"user_name"  validate:"required,min=1"`,
+			want: []StructTag{
+				{Key: "json", Value: "name", Name: "name"},
+				{Key: "db", Value: "user_name", Name: "user_name"},
+				{Key: "validate", Value: "required,min=1", Name: "required", Options: []string{"min=1"}},
+			},
+		},
+		"escaped quote": {
+			tag: `default:"say \"hi\""`,
+			want: []StructTag{
+				{Key: "default", Value: `say "hi"`, Name: `say "hi"`},
+			},
+		},
+		"skip name": {
+			tag: `json:"-"`,
+			want: []StructTag{
+				{Key: "json", Value: "-", Name: "-"},
+			},
+		},
+		"malformed pair stops parsing": {
+			tag: `json:"id" broken yaml:"id"`,
+			want: []StructTag{
+				{Key: "json", Value: "id", Name: "id"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseStructTag(tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStructTag() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_GetStructInfo_tagsAndLayout(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"dto/dto.go": `package dto
+
+// User is a user row.
+type User struct {
+	Active bool   ` + "`json:\"active\" db:\"active\"`" + `
+	ID     int64  ` + "`json:\"id,omitempty\" db:\"id\"`" + `
+	Name   string
+}
+
+// Box holds a value.
+type Box[T any] struct {
+	Value T ` + "`json:\"value\"`" + `
+}
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	info, err := p.GetStructInfo("example.com/m/dto", "User")
+	if err != nil {
+		t.Fatalf("GetStructInfo() error = %v", err)
+	}
+	id := info.Fields[1]
+	if want := `json:"id,omitempty" db:"id"`; id.Tag != want {
+		t.Errorf("Tag = %q, want %q", id.Tag, want)
+	}
+	wantTags := []StructTag{
+		{Key: "json", Value: "id,omitempty", Name: "id", Options: []string{"omitempty"}},
+		{Key: "db", Value: "id", Name: "id"},
+	}
+	if !reflect.DeepEqual(id.Tags, wantTags) {
+		t.Errorf("Tags = %+v, want %+v", id.Tags, wantTags)
+	}
+	if info.Fields[2].Tags != nil {
+		t.Errorf("Tags of an untagged field = %+v, want nil", info.Fields[2].Tags)
+	}
+
+	// The layout depends on the architecture, so only its consistency is checked
+	if info.Layout == nil || id.Layout == nil {
+		t.Fatal("Layout = nil, want the layout of a non-generic struct")
+	}
+	cmd := exec.Command("go", "env", "GOARCH")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("go env GOARCH error = %v", err)
+	}
+	if want := strings.TrimSpace(string(out)); info.Layout.Arch != want {
+		t.Errorf("Layout.Arch = %q, want %q", info.Layout.Arch, want)
+	}
+	if id.Layout.Offset%id.Layout.Align != 0 || id.Layout.Offset < info.Fields[0].Layout.Size {
+		t.Errorf("ID layout = %+v, want an aligned offset after Active", *id.Layout)
+	}
+	last := info.Fields[2].Layout
+	if info.Layout.Size < last.Offset+last.Size {
+		t.Errorf("struct size %d is smaller than the end of the last field %d", info.Layout.Size, last.Offset+last.Size)
+	}
+
+	box, err := p.GetStructInfo("example.com/m/dto", "Box")
+	if err != nil {
+		t.Fatalf("GetStructInfo() error = %v", err)
+	}
+	if box.Layout != nil || box.Fields[0].Layout != nil {
+		t.Error("layout of a generic struct is reported")
+	}
+	if box.Fields[0].Tag != `json:"value"` {
+		t.Errorf("Tag = %q, want %q", box.Fields[0].Tag, `json:"value"`)
+	}
+}
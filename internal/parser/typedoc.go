@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeInfo represents information about a named type or type alias of any kind
+type TypeInfo struct {
+	Name         string        // Type name
+	Kind         string        // Kind of the underlying type, or "alias" for type aliases
+	Comment      string        // Type comment
+	Declaration  string        // Go declaration of the type
+	Underlying   string        // Underlying type, or the aliased type for type aliases
+	Constants    []ConstInfo   // Constants of the type, in declaration order
+	Constructors []Constructor // Functions returning the type
+	Methods      []Method      // Method set of the type, or of the aliased type
+	Examples     []Example     // Type examples
+	TypeParams   []TypeParam   // Type parameters of a generic type
+}
+
+// Constructor represents a function returning a value of a type
+type Constructor struct {
+	Name      string // Function name
+	Signature string // Function declaration
+	Comment   string // Function comment
+}
+
+// TypeKind returns the kind of a named type: "alias" for type aliases, and otherwise the
+// kind of its underlying type, such as "struct", "interface", "func", "slice" or "basic".
+func TypeKind(obj *types.TypeName) string {
+	if obj.IsAlias() {
+		return "alias"
+	}
+	switch obj.Type().Underlying().(type) {
+	case *types.Basic:
+		return "basic"
+	case *types.Struct:
+		return "struct"
+	case *types.Interface:
+		return "interface"
+	case *types.Signature:
+		return "func"
+	case *types.Slice:
+		return "slice"
+	case *types.Array:
+		return "array"
+	case *types.Map:
+		return "map"
+	case *types.Chan:
+		return "chan"
+	case *types.Pointer:
+		return "pointer"
+	}
+	return "type"
+}
+
+// GetTypeInfo returns information about a named type or type alias in the specified package.
+// Constants and constructors are associated with a type the way go doc does: constants whose
+// type is the type, and functions whose only result type declared in the package is the type.
+func (p *Parser) GetTypeInfo(pkgPath, typeName string) (*TypeInfo, error) {
+	pkg, err := p.GetPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get type information from the package
+	obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type not found: %s in package %s", typeName, pkgPath)
+	}
+
+	// Build type information
+	qf := qualifier(pkg.Types)
+	info := &TypeInfo{
+		Name:         typeName,
+		Kind:         TypeKind(obj),
+		Comment:      p.GetComment(pkg, obj),
+		Declaration:  declaration(pkg, obj),
+		Underlying:   types.TypeString(obj.Type().Underlying(), qf),
+		Constants:    make([]ConstInfo, 0),
+		Constructors: make([]Constructor, 0),
+		Methods:      make([]Method, 0),
+		Examples:     p.GetExamples(pkg, typeName),
+	}
+	if alias, ok := obj.Type().(*types.Alias); ok {
+		info.Underlying = types.TypeString(alias.Rhs(), qf)
+		info.TypeParams = getTypeParams(alias.TypeParams(), qf)
+	}
+
+	// Constants, constructors and methods belong to the named type, which an alias may refer to
+	named, ok := types.Unalias(obj.Type()).(*types.Named)
+	if !ok {
+		return info, nil
+	}
+	named = named.Origin()
+	if !obj.IsAlias() {
+		info.TypeParams = getTypeParams(named.TypeParams(), qf)
+	}
+	declPkg := p.packageOf(pkg, named.Obj())
+
+	info.Methods = p.methodSet(declPkg, named)
+
+	declQf := qualifier(declPkg.Types)
+	info.Constants = p.typeConstants(declPkg, named, declQf)
+	info.Constructors = p.typeConstructors(declPkg, named)
+
+	return info, nil
+}
+
+// typeConstants returns the package-level constants of type named, in declaration order
+// so that iota sequences read naturally.
+func (p *Parser) typeConstants(pkg *packages.Package, named *types.Named, qf types.Qualifier) []ConstInfo {
+	scope := pkg.Types.Scope()
+	objs := make([]*types.Const, 0)
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), named) {
+			objs = append(objs, c)
+		}
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].Pos() < objs[j].Pos() })
+
+	constants := make([]ConstInfo, 0, len(objs))
+	for _, c := range objs {
+		constants = append(constants, ConstInfo{
+			Name:        c.Name(),
+			Type:        types.TypeString(c.Type(), qf),
+			Value:       c.Val().String(),
+			Comment:     p.GetComment(pkg, c),
+			Declaration: declaration(pkg, c),
+		})
+	}
+	return constants
+}
+
+// typeConstructors returns the package-level functions returning named or a pointer to it
+// whose other results are not of types declared in the package.
+func (p *Parser) typeConstructors(pkg *packages.Package, named *types.Named) []Constructor {
+	scope := pkg.Types.Scope()
+	constructors := make([]Constructor, 0)
+	for _, name := range scope.Names() {
+		fn, ok := scope.Lookup(name).(*types.Func)
+		if !ok {
+			continue
+		}
+		if resultType(pkg.Types, fn.Type().(*types.Signature)) != named.Obj() {
+			continue
+		}
+		constructors = append(constructors, Constructor{
+			Name:      name,
+			Signature: declaration(pkg, fn),
+			Comment:   p.GetComment(pkg, fn),
+		})
+	}
+	return constructors
+}
+
+// resultType returns the type declared in pkg that the results of sig refer to, directly or
+// through a pointer, or nil if there is none or more than one.
+func resultType(pkg *types.Package, sig *types.Signature) *types.TypeName {
+	var found *types.TypeName
+	for i := 0; i < sig.Results().Len(); i++ {
+		t := sig.Results().At(i).Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok || named.Obj().Pkg() != pkg {
+			continue
+		}
+		if found != nil && found != named.Obj() {
+			return nil
+		}
+		found = named.Obj()
+	}
+	return found
+}
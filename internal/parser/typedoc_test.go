@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_GetTypeInfo(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModule(t, map[string]string{
+		"mode/mode.go": `package mode
+
+import "errors"
+
+// Mode is a file mode.
+type Mode int
+
+// Modes of a file.
+const (
+	Read  Mode = iota // Read only
+	Write             // Write only
+)
+
+// Default is the default mode.
+const Default = Read
+
+// Limit is not a mode.
+const Limit = 10
+
+// Parse parses a mode.
+func Parse(s string) (Mode, error) { return 0, errors.New("invalid") }
+
+// Convert converts a mode to a handler.
+func Convert(m Mode) HandlerFunc { return nil }
+
+// String returns the name of the mode.
+func (m Mode) String() string { return "" }
+
+// HandlerFunc handles a mode.
+type HandlerFunc func(m Mode) error
+
+// IDs are identifiers.
+type IDs = []string
+
+// Alias refers to Mode.
+type Alias = Mode
+
+// List is a list.
+type List[T any] []T
+
+// NewList returns an empty list.
+func NewList[T any]() *List[T] { return &List[T]{} }
+`,
+	})
+	p, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	modeConstants := []ConstInfo{
+		{Name: "Read", Type: "Mode", Value: "0", Comment: "Read only", Declaration: "const (\n\tRead  Mode = iota // Read only\n\tWrite             // Write only\n)"},
+		{Name: "Write", Type: "Mode", Value: "1", Comment: "Write only", Declaration: "const (\n\tRead  Mode = iota // Read only\n\tWrite             // Write only\n)"},
+		{Name: "Default", Type: "Mode", Value: "0", Comment: "Default is the default mode.", Declaration: "const Default = Read"},
+	}
+	modeConstructors := []Constructor{
+		{Name: "Parse", Signature: "func Parse(s string) (Mode, error)", Comment: "Parse parses a mode."},
+	}
+	modeMethods := []Method{
+		{Name: "String", Signature: "func (m Mode) String() string", Comment: "String returns the name of the mode."},
+	}
+
+	tests := map[string]struct {
+		typeName string
+		want     TypeInfo
+	}{
+		"basic type with constants": {
+			typeName: "Mode",
+			want: TypeInfo{
+				Name: "Mode", Kind: "basic", Comment: "Mode is a file mode.", Declaration: "type Mode int", Underlying: "int",
+				Constants: modeConstants, Constructors: modeConstructors, Methods: modeMethods,
+			},
+		},
+		"func type": {
+			typeName: "HandlerFunc",
+			want: TypeInfo{
+				Name: "HandlerFunc", Kind: "func", Comment: "HandlerFunc handles a mode.", Declaration: "type HandlerFunc func(m Mode) error", Underlying: "func(m Mode) error",
+				Constants: []ConstInfo{}, Methods: []Method{},
+				Constructors: []Constructor{
+					{Name: "Convert", Signature: "func Convert(m Mode) HandlerFunc", Comment: "Convert converts a mode to a handler."},
+				},
+			},
+		},
+		"alias of an unnamed type": {
+			typeName: "IDs",
+			want: TypeInfo{
+				Name: "IDs", Kind: "alias", Comment: "IDs are identifiers.", Declaration: "type IDs = []string", Underlying: "[]string",
+				Constants: []ConstInfo{}, Constructors: []Constructor{}, Methods: []Method{},
+			},
+		},
+		"alias of a named type": {
+			typeName: "Alias",
+			want: TypeInfo{
+				Name: "Alias", Kind: "alias", Comment: "Alias refers to Mode.", Declaration: "type Alias = Mode", Underlying: "Mode",
+				Constants: modeConstants, Constructors: modeConstructors, Methods: modeMethods,
+			},
+		},
+		"generic type": {
+			typeName: "List",
+			want: TypeInfo{
+				Name: "List", Kind: "slice", Comment: "List is a list.", Declaration: "type List[T any] []T", Underlying: "[]T",
+				Constants: []ConstInfo{},
+				Constructors: []Constructor{
+					{Name: "NewList", Signature: "func NewList[T any]() *List[T]", Comment: "NewList returns an empty list."},
+				},
+				Methods:    []Method{},
+				TypeParams: []TypeParam{{Name: "T", Constraint: "any"}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := p.GetTypeInfo("example.com/m/mode", tt.typeName)
+			if err != nil {
+				t.Fatalf("GetTypeInfo() error = %v", err)
+			}
+			tt.want.Examples = []Example{}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("GetTypeInfo() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	t.Run("not a type", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := p.GetTypeInfo("example.com/m/mode", "Parse"); err == nil {
+			t.Error("GetTypeInfo() error = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,280 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Workspace serves the packages of several modules, each loaded by its own Parser.
+// Requests for a package are routed to the parser of the module it belongs to.
+type Workspace struct {
+	opts Options // Options of the parsers of the modules
+
+	mu      sync.RWMutex
+	parsers []*Parser // Parsers in the order of their root directories
+}
+
+// NewWorkspace creates a Workspace loading the module in each of rootDirs as configured by opts.
+// File paths are reported relative to the deepest directory containing every root directory,
+// so that they identify the module of the file.
+func NewWorkspace(rootDirs []string, opts Options) (*Workspace, error) {
+	if len(rootDirs) == 0 {
+		return nil, fmt.Errorf("no root directory")
+	}
+
+	baseDir := commonDir(rootDirs)
+	parsers := make([]*Parser, 0, len(rootDirs))
+	for _, dir := range rootDirs {
+		p, err := NewWithOptions(dir, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", dir, err)
+		}
+		p.baseDir = baseDir
+		parsers = append(parsers, p)
+	}
+	return &Workspace{opts: opts, parsers: parsers}, nil
+}
+
+// commonDir returns the deepest directory containing every directory of dirs.
+func commonDir(dirs []string) string {
+	common := filepath.Clean(dirs[0])
+	for _, dir := range dirs[1:] {
+		dir = filepath.Clean(dir)
+		for {
+			rel, err := filepath.Rel(common, dir)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				break
+			}
+			parent := filepath.Dir(common)
+			if parent == common {
+				break
+			}
+			common = parent
+		}
+	}
+	return common
+}
+
+// Parsers returns the parsers of the modules, in the order of their root directories.
+func (w *Workspace) Parsers() []*Parser {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.parsers
+}
+
+// SetModules replaces the modules of the workspace by the modules in rootDirs.
+// Modules that are already loaded are kept, new modules are loaded, and the packages of
+// the modules that are no longer listed are released.
+// Tool calls in flight keep using the previous modules until loading completes.
+func (w *Workspace) SetModules(rootDirs []string) error {
+	if len(rootDirs) == 0 {
+		return fmt.Errorf("no root directory")
+	}
+
+	loaded := make(map[string]*Parser)
+	for _, p := range w.Parsers() {
+		loaded[p.rootDir] = p
+	}
+	baseDir := commonDir(rootDirs)
+	parsers := make([]*Parser, 0, len(rootDirs))
+	for _, dir := range rootDirs {
+		p, ok := loaded[dir]
+		if !ok {
+			var err error
+			if p, err = NewWithOptions(dir, w.opts); err != nil {
+				return fmt.Errorf("failed to load %s: %w", dir, err)
+			}
+		}
+		p.setBaseDir(baseDir)
+		parsers = append(parsers, p)
+	}
+
+	w.mu.Lock()
+	w.parsers = parsers
+	w.mu.Unlock()
+	return nil
+}
+
+// Parser returns the parser serving the package with the specified path.
+// Packages under a root directory are served by its parser, and other packages of a
+// module by the parser of that module. Dependencies are served by the first parser
+// that loaded them, and packages loaded on request by the first parser.
+func (w *Workspace) Parser(pkgPath string) *Parser {
+	parsers := w.Parsers()
+	for _, p := range parsers {
+		if p.IsLocal(pkgPath) {
+			return p
+		}
+	}
+
+	// Packages of a module that are not loaded, such as new packages, belong to the innermost module
+	var module *Parser
+	for _, p := range parsers {
+		modulePath := p.ModulePath()
+		if modulePath == "" || (pkgPath != modulePath && !strings.HasPrefix(pkgPath, modulePath+"/")) {
+			continue
+		}
+		if module == nil || len(modulePath) > len(module.ModulePath()) {
+			module = p
+		}
+	}
+	if module != nil {
+		return module
+	}
+
+	for _, p := range parsers {
+		if _, ok := p.dependencies()[pkgPath]; ok {
+			return p
+		}
+	}
+	return parsers[0]
+}
+
+// IsLocal reports whether the package is under one of the root directories.
+func (w *Workspace) IsLocal(pkgPath string) bool {
+	parsers := w.Parsers()
+	for _, p := range parsers {
+		if p.IsLocal(pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDependencyPackages returns the packages that are not under any root directory but have been
+// loaded as dependencies or on request, sorted by package path.
+func (w *Workspace) GetDependencyPackages() []*packages.Package {
+	seen := make(map[string]bool)
+	var result []*packages.Package
+	for _, p := range w.Parsers() {
+		for _, pkg := range p.GetDependencyPackages() {
+			if seen[pkg.PkgPath] || w.IsLocal(pkg.PkgPath) {
+				continue
+			}
+			seen[pkg.PkgPath] = true
+			result = append(result, pkg)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PkgPath < result[j].PkgPath
+	})
+	return result
+}
+
+// Reload reloads the packages affected by the changed files in every module.
+func (w *Workspace) Reload(changed []string) error {
+	var errs []string
+	for _, p := range w.Parsers() {
+		if err := p.Reload(changed); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", p.RootDir(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to reload modules: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// SearchSymbols searches the symbols of every module, ranked as by Parser.SearchSymbols.
+func (w *Workspace) SearchSymbols(query, kind string, limit int) []Symbol {
+	parsers := w.Parsers()
+	if len(parsers) == 1 {
+		return parsers[0].SearchSymbols(query, kind, limit)
+	}
+
+	symbols := make([]Symbol, 0)
+	for _, p := range parsers {
+		symbols = append(symbols, p.SearchSymbols(query, kind, limit)...)
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		a, b := symbols[i], symbols[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		if a.PkgPath != b.PkgPath {
+			return a.PkgPath < b.PkgPath
+		}
+		return a.Name < b.Name
+	})
+	if limit > 0 && len(symbols) > limit {
+		symbols = symbols[:limit]
+	}
+	return symbols
+}
+
+// SearchDocs searches the doc comments of every module.
+// Each module is indexed separately, so scores from different modules are only roughly comparable.
+func (w *Workspace) SearchDocs(query string, limit int) []DocMatch {
+	parsers := w.Parsers()
+	if len(parsers) == 1 {
+		return parsers[0].SearchDocs(query, limit)
+	}
+
+	matches := make([]DocMatch, 0)
+	for _, p := range parsers {
+		matches = append(matches, p.SearchDocs(query, limit)...)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// GetDiagnostics returns the errors of the packages of every module, sorted by package path.
+// Dependencies that are shared by several modules or are packages of another module are reported once.
+func (w *Workspace) GetDiagnostics(includeDeps bool) []PackageDiagnostics {
+	parsers := w.Parsers()
+	if len(parsers) == 1 {
+		return parsers[0].GetDiagnostics(includeDeps)
+	}
+
+	diagnostics := make([]PackageDiagnostics, 0)
+	seen := make(map[string]bool)
+	for _, p := range parsers {
+		for _, d := range p.GetDiagnostics(includeDeps) {
+			if seen[d.PkgPath] || (d.IsDependency && w.IsLocal(d.PkgPath)) {
+				continue
+			}
+			seen[d.PkgPath] = true
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	sort.Slice(diagnostics, func(i, j int) bool {
+		return diagnostics[i].PkgPath < diagnostics[j].PkgPath
+	})
+	return diagnostics
+}
+
+// GetImportGraph returns the import graph of the specified package, or of the packages of every
+// module if pkgPath is empty, as described for Parser.GetImportGraph.
+// Packages of every module are reported as under the root directories.
+func (w *Workspace) GetImportGraph(pkgPath string, transitive, includeDeps bool, rules []LayerRule) (*ImportGraph, error) {
+	parsers := w.Parsers()
+	if len(parsers) == 1 {
+		return parsers[0].GetImportGraph(pkgPath, transitive, includeDeps, rules)
+	}
+
+	imports := make(map[string][]string)
+	local := make(map[string]bool)
+	for _, p := range parsers {
+		moduleImports, moduleLocal := p.importMap()
+		for path, imported := range moduleImports {
+			imports[path] = imported
+		}
+		for path := range moduleLocal {
+			local[path] = true
+		}
+	}
+	return importGraph(imports, local, pkgPath, transitive, includeDeps, rules)
+}
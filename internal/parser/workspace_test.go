@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWorkspace(t *testing.T) {
+	// Workspace mode rejects -mod=mod, which may be set in the environment
+	t.Setenv("GOFLAGS", "")
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.work":     "go 1.24\n\nuse (\n\t./api\n\t./app\n)\n",
+		"api/go.mod":  "module example.com/api\n\ngo 1.24\n",
+		"api/api.go":  "package api\n\n// Request is a request.\ntype Request struct{}\n",
+		"app/go.mod":  "module example.com/app\n\ngo 1.24\n",
+		"app/main.go": "package main\n\nimport \"example.com/api\"\n\n// Handle handles a request.\nfunc Handle(api.Request) {}\n\nfunc main() {}\n",
+	})
+	w, err := NewWorkspace([]string{filepath.Join(dir, "api"), filepath.Join(dir, "app")}, Options{})
+	if err != nil {
+		t.Fatalf("NewWorkspace() error = %v", err)
+	}
+	api, app := w.Parsers()[0], w.Parsers()[1]
+	if api.ModulePath() != "example.com/api" || app.ModulePath() != "example.com/app" {
+		t.Fatalf("ModulePath() = %q, %q, want example.com/api, example.com/app", api.ModulePath(), app.ModulePath())
+	}
+
+	// Packages are served by the parser of their module
+	tests := map[string]struct {
+		pkgPath string
+		want    *Parser
+	}{
+		"package of the first module":  {pkgPath: "example.com/api", want: api},
+		"package of the second module": {pkgPath: "example.com/app", want: app},
+		"new package of a module":      {pkgPath: "example.com/app/internal/store", want: app},
+		"standard library":             {pkgPath: "net/http", want: api},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := w.Parser(tt.pkgPath); got != tt.want {
+				t.Errorf("Parser(%q) = parser of %s, want parser of %s", tt.pkgPath, got.RootDir(), tt.want.RootDir())
+			}
+		})
+	}
+
+	// Packages of another module are not dependencies
+	for _, pkg := range w.GetDependencyPackages() {
+		if pkg.PkgPath == "example.com/api" {
+			t.Errorf("GetDependencyPackages() includes example.com/api of the workspace")
+		}
+	}
+
+	// Positions are relative to the workspace directory
+	symbols := w.SearchSymbols("Handle", "", 1)
+	if len(symbols) != 1 || symbols[0].Position != "app/main.go:6" {
+		t.Errorf("SearchSymbols() = %+v, want Handle at app/main.go:6", symbols)
+	}
+
+	graph, err := w.GetImportGraph("", false, false, nil)
+	if err != nil {
+		t.Fatalf("GetImportGraph() error = %v", err)
+	}
+	wantImports := []ImportEdge{{From: "example.com/app", To: "example.com/api"}}
+	if !reflect.DeepEqual(graph.Imports, wantImports) {
+		t.Errorf("GetImportGraph() imports = %+v, want %+v", graph.Imports, wantImports)
+	}
+
+	// Changes to a module reload the packages of other modules importing it
+	oldApp, err := app.GetPackage("example.com/app")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	writeFiles(t, dir, map[string]string{
+		"api/api.go": "package api\n\n// Request is a request.\ntype Request struct{ Path string }\n",
+	})
+	if err := w.Reload([]string{filepath.Join(dir, "api", "api.go")}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	newApp, err := app.GetPackage("example.com/app")
+	if err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	if newApp == oldApp {
+		t.Errorf("Reload() did not reload example.com/app")
+	}
+
+	// Modules that are no longer served are released, and the others kept
+	if err := w.SetModules([]string{filepath.Join(dir, "api")}); err != nil {
+		t.Fatalf("SetModules() error = %v", err)
+	}
+	if parsers := w.Parsers(); len(parsers) != 1 || parsers[0] != api {
+		t.Errorf("Parsers() after SetModules() = %v, want the parser of example.com/api", parsers)
+	}
+	if w.IsLocal("example.com/app") {
+		t.Errorf("IsLocal() of a released module = true, want false")
+	}
+	symbols = w.SearchSymbols("Request", "", 1)
+	if len(symbols) != 1 || symbols[0].Position != "api.go:4" {
+		t.Errorf("SearchSymbols() = %+v, want Request at api.go:4", symbols)
+	}
+}
@@ -0,0 +1,557 @@
+// Package transport serves MCP over HTTP, with the Streamable HTTP transport and the legacy HTTP+SSE transport.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	mcp "github.com/ktr0731/go-mcp"
+	"github.com/ktr0731/go-mcp/protocol"
+	jsonrpc2 "golang.org/x/exp/jsonrpc2"
+)
+
+// Transport names
+const (
+	Stdio          = "stdio" // Standard input and output, for a single client
+	StreamableHTTP = "http"  // Streamable HTTP
+	SSE            = "sse"   // HTTP with Server-Sent Events, superseded by Streamable HTTP
+)
+
+// SessionHeader is the header carrying the session ID of Streamable HTTP requests.
+const SessionHeader = "Mcp-Session-Id"
+
+// DefaultSessionTimeout is the time after which an idle Streamable HTTP session is closed.
+const DefaultSessionTimeout = 30 * time.Minute
+
+// streamBuffer is the number of server messages buffered for a client event stream.
+const streamBuffer = 64
+
+// NewHTTPTransport creates a transport serving handler to HTTP clients.
+// Every client session is accepted by the returned listener as a separate connection,
+// so one server can serve several clients. Requests reach the listener through the
+// HTTP handlers it provides.
+func NewHTTPTransport(ctx context.Context, handler *mcp.Handler) (context.Context, *Listener, jsonrpc2.Binder) {
+	// Log messages are not sent to HTTP clients
+	ctx = mcp.SetLogWriterToContext(ctx, io.Discard)
+	listener := &Listener{
+		accept:         make(chan *session),
+		closed:         make(chan struct{}),
+		sessions:       make(map[string]*session),
+		sessionTimeout: DefaultSessionTimeout,
+	}
+	return ctx, listener, &binder{handler: handler}
+}
+
+// binder binds connections to the MCP handler.
+type binder struct {
+	handler jsonrpc2.Handler
+}
+
+// Bind implements jsonrpc2.Binder.
+// The raw framer writes every message with a single Write call, which sessions rely on.
+func (b *binder) Bind(ctx context.Context, conn *jsonrpc2.Connection) (jsonrpc2.ConnectionOptions, error) {
+	return jsonrpc2.ConnectionOptions{
+		Framer:  jsonrpc2.RawFramer(),
+		Handler: b.handler,
+	}, nil
+}
+
+// Listener is a jsonrpc2.Listener accepting the sessions of HTTP clients.
+type Listener struct {
+	accept    chan *session
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mu             sync.Mutex
+	sessions       map[string]*session
+	sessionTimeout time.Duration
+}
+
+// Accept implements jsonrpc2.Listener.
+func (l *Listener) Accept(ctx context.Context) (io.ReadWriteCloser, error) {
+	select {
+	case s := <-l.accept:
+		return s, nil
+	case <-l.closed:
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close implements jsonrpc2.Listener. Open sessions are closed.
+func (l *Listener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		l.mu.Lock()
+		sessions := make([]*session, 0, len(l.sessions))
+		for _, s := range l.sessions {
+			sessions = append(sessions, s)
+		}
+		l.mu.Unlock()
+		for _, s := range sessions {
+			s.Close()
+		}
+	})
+	return nil
+}
+
+// Dialer implements jsonrpc2.Listener. Sessions are only opened by HTTP clients.
+func (l *Listener) Dialer() jsonrpc2.Dialer {
+	return nil
+}
+
+// StreamableHTTPHandler returns the handler of the Streamable HTTP endpoint.
+// Clients post messages and receive the responses in the reply, open a stream of
+// server messages with GET, and end their session with DELETE.
+func (l *Listener) StreamableHTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedOrigin(r) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			l.handlePost(w, r)
+		case http.MethodGet:
+			s, ok := l.requestSession(w, r)
+			if !ok {
+				return
+			}
+			writeEvents(w, r, s, nil)
+		case http.MethodDelete:
+			s, ok := l.requestSession(w, r)
+			if !ok {
+				return
+			}
+			s.Close()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// SSEHandler returns the handler of the event stream of the HTTP+SSE transport.
+// Every stream is a session, whose messages are posted to messagePath.
+func (l *Listener) SSEHandler(messagePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedOrigin(r) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s, err := l.open(true)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer s.Close()
+
+		endpoint := messagePath + "?sessionId=" + url.QueryEscape(s.id)
+		writeEvents(w, r, s, &endpoint)
+	})
+}
+
+// MessageHandler returns the handler receiving the messages of the HTTP+SSE transport.
+// Responses are sent on the event stream of the session.
+func (l *Listener) MessageHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedOrigin(r) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s, ok := l.session(r.URL.Query().Get("sessionId"))
+		if !ok {
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+		msgs, _, err := readMessages(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, msg := range msgs {
+			if err := s.deliver(msg.raw); err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// handlePost handles messages posted to the Streamable HTTP endpoint.
+// A session is opened by an initialize request, and its ID is returned in SessionHeader.
+func (l *Listener) handlePost(w http.ResponseWriter, r *http.Request) {
+	msgs, batch, err := readMessages(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var s *session
+	if len(msgs) == 1 && msgs[0].Method == protocol.MethodInitialize && r.Header.Get(SessionHeader) == "" {
+		if s, err = l.open(false); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set(SessionHeader, s.id)
+	} else {
+		var ok bool
+		if s, ok = l.requestSession(w, r); !ok {
+			return
+		}
+	}
+	s.touch()
+
+	// Register the requests before delivering them, so that no response is missed
+	var waits []chan json.RawMessage
+	for _, msg := range msgs {
+		if msg.isCall() {
+			waits = append(waits, s.await(msg.ID))
+			defer s.forget(msg.ID)
+		}
+	}
+	for _, msg := range msgs {
+		if err := s.deliver(msg.raw); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	}
+	if len(waits) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	responses := make([]json.RawMessage, 0, len(waits))
+	for _, wait := range waits {
+		select {
+		case res := <-wait:
+			responses = append(responses, res)
+		case <-s.done:
+			http.Error(w, "session closed", http.StatusNotFound)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	var body []byte
+	if batch {
+		body, err = json.Marshal(responses)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		body = responses[0]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// requestSession returns the session of a Streamable HTTP request, replying with an error if there is none.
+func (l *Listener) requestSession(w http.ResponseWriter, r *http.Request) (*session, bool) {
+	id := r.Header.Get(SessionHeader)
+	if id == "" {
+		http.Error(w, "missing "+SessionHeader+" header", http.StatusBadRequest)
+		return nil, false
+	}
+	s, ok := l.session(id)
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return nil, false
+	}
+	return s, true
+}
+
+// session returns the open session with the specified ID.
+func (l *Listener) session(id string) (*session, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.sessions[id]
+	return s, ok
+}
+
+// open opens a session and hands it to Accept. Messages of lossless sessions that no
+// request awaits are held until the client reads them from its event stream, while the
+// others are dropped if the client does not read them.
+func (l *Listener) open(lossless bool) (*session, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	in, inWriter := io.Pipe()
+	s := &session{
+		id:       id,
+		in:       in,
+		inWriter: inWriter,
+		lossless: lossless,
+		pending:  make(map[string]chan json.RawMessage),
+		stream:   make(chan json.RawMessage, streamBuffer),
+		done:     make(chan struct{}),
+	}
+	s.onClose = func() {
+		l.mu.Lock()
+		delete(l.sessions, id)
+		l.mu.Unlock()
+	}
+	if !lossless {
+		s.timeout = l.sessionTimeout
+		s.idle = time.AfterFunc(s.timeout, func() { s.Close() })
+	}
+
+	l.mu.Lock()
+	l.sessions[id] = s
+	l.mu.Unlock()
+
+	select {
+	case l.accept <- s:
+		return s, nil
+	case <-l.closed:
+		s.Close()
+		return nil, errors.New("server is shutting down")
+	}
+}
+
+// session is a client session, served as a connection whose input is the messages
+// posted by the client and whose output is routed to the requests awaiting it or to
+// the event stream of the client.
+type session struct {
+	id       string
+	in       *io.PipeReader
+	inWriter *io.PipeWriter
+	lossless bool
+	timeout  time.Duration // Idle timeout, zero for sessions ending with their event stream
+	idle     *time.Timer
+	onClose  func()
+
+	mu      sync.Mutex
+	pending map[string]chan json.RawMessage // Requests awaiting their response, by ID
+	stream  chan json.RawMessage            // Messages for the event stream of the client
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Read implements io.Reader, reading the messages posted by the client.
+func (s *session) Read(p []byte) (int, error) {
+	return s.in.Read(p)
+}
+
+// Write implements io.Writer. Every call writes a single message.
+func (s *session) Write(p []byte) (int, error) {
+	msg := make(json.RawMessage, len(p))
+	copy(msg, p)
+
+	var header struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.Unmarshal(msg, &header); err == nil && header.Method == "" && len(header.ID) > 0 {
+		s.mu.Lock()
+		wait, ok := s.pending[string(header.ID)]
+		s.mu.Unlock()
+		if ok {
+			wait <- msg
+			return len(p), nil
+		}
+	}
+
+	if s.lossless {
+		select {
+		case s.stream <- msg:
+		case <-s.done:
+			return 0, io.ErrClosedPipe
+		}
+	} else {
+		select {
+		case s.stream <- msg:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Close implements io.Closer. The connection ends once it has read all posted messages.
+func (s *session) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		if s.idle != nil {
+			s.idle.Stop()
+		}
+		s.inWriter.Close()
+		s.onClose()
+	})
+	return nil
+}
+
+// deliver passes a message posted by the client to the connection.
+func (s *session) deliver(msg json.RawMessage) error {
+	if _, err := s.inWriter.Write(msg); err != nil {
+		return errors.New("session closed")
+	}
+	return nil
+}
+
+// await registers a request, whose response is sent on the returned channel.
+func (s *session) await(id json.RawMessage) chan json.RawMessage {
+	wait := make(chan json.RawMessage, 1)
+	s.mu.Lock()
+	s.pending[string(id)] = wait
+	s.mu.Unlock()
+	return wait
+}
+
+// forget unregisters a request.
+func (s *session) forget(id json.RawMessage) {
+	s.mu.Lock()
+	delete(s.pending, string(id))
+	s.mu.Unlock()
+}
+
+// touch postpones the idle timeout of the session.
+func (s *session) touch() {
+	if s.idle != nil {
+		s.idle.Reset(s.timeout)
+	}
+}
+
+// message is a JSON-RPC message posted by a client.
+type message struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	raw    json.RawMessage
+}
+
+// isCall reports whether the message is a request expecting a response.
+func (m message) isCall() bool {
+	return m.Method != "" && len(m.ID) > 0 && string(m.ID) != "null"
+}
+
+// readMessages reads a JSON-RPC message or batch of messages, and reports whether it was a batch.
+func readMessages(body io.Reader) ([]message, bool, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read request: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+
+	var raws []json.RawMessage
+	batch := len(data) > 0 && data[0] == '['
+	if batch {
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, false, fmt.Errorf("invalid JSON-RPC batch: %w", err)
+		}
+	} else {
+		raws = []json.RawMessage{data}
+	}
+	if len(raws) == 0 {
+		return nil, false, errors.New("empty JSON-RPC batch")
+	}
+
+	msgs := make([]message, 0, len(raws))
+	for _, raw := range raws {
+		var msg message
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return nil, false, fmt.Errorf("invalid JSON-RPC message: %w", err)
+		}
+		// Compact the message, since the connection reads concatenated messages
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return nil, false, fmt.Errorf("invalid JSON-RPC message: %w", err)
+		}
+		msg.raw = compact.Bytes()
+		var id bytes.Buffer
+		if len(msg.ID) > 0 {
+			json.Compact(&id, msg.ID)
+			msg.ID = id.Bytes()
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, batch, nil
+}
+
+// writeEvents streams the messages of a session to the client as Server-Sent Events
+// until the client disconnects or the session is closed. If endpoint is not nil,
+// it is sent first as the endpoint event of the HTTP+SSE transport.
+func writeEvents(w http.ResponseWriter, r *http.Request, s *session, endpoint *string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if endpoint != nil {
+		fmt.Fprintf(w, "event: endpoint\ndata: %s\n\n", *endpoint)
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case msg := <-s.stream:
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg)
+			flusher.Flush()
+		case <-s.done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// allowedOrigin reports whether a request comes from a page of the server's own host or of
+// the local machine, to prevent DNS rebinding attacks. Requests without an Origin are allowed.
+func allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if u.Host == r.Host {
+		return true
+	}
+	switch host := u.Hostname(); host {
+	case "localhost":
+		return true
+	default:
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	}
+}
+
+// newSessionID returns a random session ID.
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,232 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mcp "github.com/ktr0731/go-mcp"
+	"github.com/ktr0731/go-mcp/protocol"
+	jsonrpc2 "golang.org/x/exp/jsonrpc2"
+)
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+
+// serve starts a server for a handler without tools, returning the listener of its transport.
+func serve(t *testing.T) *Listener {
+	t.Helper()
+
+	handler := &mcp.Handler{
+		Implementation: protocol.Implementation{Name: "test", Version: "1.0.0"},
+		Capabilities:   protocol.ServerCapabilities{Tools: &protocol.ToolCapability{}},
+	}
+	ctx, listener, binder := NewHTTPTransport(context.Background(), handler)
+	srv, err := jsonrpc2.Serve(ctx, listener, binder)
+	if err != nil {
+		t.Fatalf("jsonrpc2.Serve() error = %v", err)
+	}
+	t.Cleanup(func() {
+		listener.Close()
+		srv.Wait()
+	})
+	return listener
+}
+
+// post posts body to url, with the session ID if it is not empty.
+func post(t *testing.T, url, sessionID, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set(SessionHeader, sessionID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+// readEvent reads the next Server-Sent Event of a stream.
+func readEvent(t *testing.T, r *bufio.Reader) (event, data string) {
+	t.Helper()
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestListener_StreamableHTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(serve(t).StreamableHTTPHandler())
+	t.Cleanup(server.Close)
+
+	// Requests other than initialize need a session
+	if res := post(t, server.URL, "", `{"jsonrpc":"2.0","id":1,"method":"ping"}`); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("POST without session status = %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+	if res := post(t, server.URL, "unknown", `{"jsonrpc":"2.0","id":1,"method":"ping"}`); res.StatusCode != http.StatusNotFound {
+		t.Errorf("POST with unknown session status = %d, want %d", res.StatusCode, http.StatusNotFound)
+	}
+
+	// Clients are served in separate sessions
+	var sessions []string
+	for range 2 {
+		res := post(t, server.URL, "", initializeRequest)
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("initialize status = %d, want %d", res.StatusCode, http.StatusOK)
+		}
+		sessionID := res.Header.Get(SessionHeader)
+		if sessionID == "" {
+			t.Fatalf("initialize did not return a session ID")
+		}
+		var result struct {
+			ID     int `json:"id"`
+			Result struct {
+				ServerInfo struct {
+					Name string `json:"name"`
+				} `json:"serverInfo"`
+			} `json:"result"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode initialize response: %v", err)
+		}
+		if result.ID != 1 || result.Result.ServerInfo.Name != "test" {
+			t.Errorf("initialize response = %+v, want the server info with ID 1", result)
+		}
+		sessions = append(sessions, sessionID)
+	}
+	if sessions[0] == sessions[1] {
+		t.Errorf("sessions share the ID %s", sessions[0])
+	}
+
+	// Notifications are accepted without a response
+	if res := post(t, server.URL, sessions[0], `{"jsonrpc":"2.0","method":"notifications/initialized"}`); res.StatusCode != http.StatusAccepted {
+		t.Errorf("notification status = %d, want %d", res.StatusCode, http.StatusAccepted)
+	}
+
+	// Batches are answered with a batch
+	res := post(t, server.URL, sessions[0], `[{"jsonrpc":"2.0","id":2,"method":"ping"},{"jsonrpc":"2.0","id":"3","method":"tools/list"}]`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("batch status = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	var batch []struct {
+		ID     json.RawMessage `json:"id"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&batch); err != nil {
+		t.Fatalf("failed to decode batch response: %v", err)
+	}
+	if len(batch) != 2 || string(batch[0].ID) != "2" || string(batch[1].ID) != `"3"` {
+		t.Errorf("batch response = %+v, want responses to 2 and \"3\"", batch)
+	}
+
+	// Ended sessions are not found
+	req, err := http.NewRequest(http.MethodDelete, server.URL, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+	req.Header.Set(SessionHeader, sessions[0])
+	deleted, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("DELETE error = %v", err)
+	}
+	deleted.Body.Close()
+	if deleted.StatusCode != http.StatusNoContent {
+		t.Errorf("DELETE status = %d, want %d", deleted.StatusCode, http.StatusNoContent)
+	}
+	if res := post(t, server.URL, sessions[0], `{"jsonrpc":"2.0","id":4,"method":"ping"}`); res.StatusCode != http.StatusNotFound {
+		t.Errorf("POST to ended session status = %d, want %d", res.StatusCode, http.StatusNotFound)
+	}
+	if res := post(t, server.URL, sessions[1], `{"jsonrpc":"2.0","id":4,"method":"ping"}`); res.StatusCode != http.StatusOK {
+		t.Errorf("POST to other session status = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+}
+
+func TestListener_SSEHandler(t *testing.T) {
+	t.Parallel()
+
+	listener := serve(t)
+	mux := http.NewServeMux()
+	mux.Handle("/sse", listener.SSEHandler("/message"))
+	mux.Handle("/message", listener.MessageHandler())
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	stream, err := http.Get(server.URL + "/sse")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	t.Cleanup(func() { stream.Body.Close() })
+	events := bufio.NewReader(stream.Body)
+
+	event, endpoint := readEvent(t, events)
+	if event != "endpoint" || !strings.HasPrefix(endpoint, "/message?sessionId=") {
+		t.Fatalf("first event = %s %q, want the message endpoint", event, endpoint)
+	}
+
+	if res := post(t, server.URL+endpoint, "", initializeRequest); res.StatusCode != http.StatusAccepted {
+		t.Fatalf("initialize status = %d, want %d", res.StatusCode, http.StatusAccepted)
+	}
+	event, data := readEvent(t, events)
+	if event != "message" || !strings.Contains(data, `"serverInfo"`) {
+		t.Errorf("response event = %s %q, want the initialize result", event, data)
+	}
+
+	if res := post(t, server.URL+"/message?sessionId=unknown", "", `{"jsonrpc":"2.0","id":2,"method":"ping"}`); res.StatusCode != http.StatusNotFound {
+		t.Errorf("POST with unknown session status = %d, want %d", res.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestAllowedOrigin(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		origin string
+		want   bool
+	}{
+		"no origin":      {origin: "", want: true},
+		"localhost":      {origin: "http://localhost:3000", want: true},
+		"loopback":       {origin: "http://127.0.0.1:3000", want: true},
+		"same host":      {origin: "http://godoc.internal:8080", want: true},
+		"other host":     {origin: "https://example.com", want: false},
+		"invalid origin": {origin: "://", want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "http://godoc.internal:8080/mcp", io.NopCloser(strings.NewReader("")))
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if got := allowedOrigin(req); got != tt.want {
+				t.Errorf("allowedOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package watcher notifies about changes to Go source files under a directory tree.
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDelay is the default time to wait for further changes before notifying.
+const DefaultDelay = 300 * time.Millisecond
+
+// Watcher watches a directory tree and reports changed Go source files.
+// Changes are batched so that saving many files at once results in a single notification.
+type Watcher struct {
+	root     string
+	delay    time.Duration
+	fsw      *fsnotify.Watcher
+	onChange func(files []string)
+}
+
+// New creates a Watcher that watches every directory under root.
+// onChange is called with the paths of the changed files once no further change
+// has been observed for delay.
+func New(root string, delay time.Duration, onChange func(files []string)) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	w := &Watcher{
+		root:     root,
+		delay:    delay,
+		fsw:      fsw,
+		onChange: onChange,
+	}
+	if err := w.addTree(root, nil); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Run processes file system events until ctx is canceled or the watcher is closed.
+// Watcher errors are logged and do not stop it.
+func (w *Watcher) Run(ctx context.Context) error {
+	pending := make(map[string]bool)
+	timer := time.NewTimer(w.delay)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// New directories are watched and the files already in them reported
+					if err := w.addTree(event.Name, pending); err != nil && !errors.Is(err, fs.ErrNotExist) {
+						log.Printf("Failed to watch %s: %v", event.Name, err)
+					}
+					timer.Reset(w.delay)
+					continue
+				}
+			}
+			if !isRelevant(event.Name) || event.Op == fsnotify.Chmod {
+				continue
+			}
+			pending[event.Name] = true
+			timer.Reset(w.delay)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+			// Errors do not stop watching. After an overflow, events were lost, so every file
+			// under the root directory is reported as changed.
+			log.Printf("File watcher error: %v", err)
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				if err := w.addTree(w.root, pending); err != nil {
+					log.Printf("Failed to watch %s: %v", w.root, err)
+				}
+				timer.Reset(w.delay)
+			}
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			files := make([]string, 0, len(pending))
+			for file := range pending {
+				files = append(files, file)
+			}
+			pending = make(map[string]bool)
+			w.onChange(files)
+		}
+	}
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+// addTree adds dir and all of its subdirectories to the watch list.
+// If found is not nil, the relevant files in the tree are added to it.
+func (w *Watcher) addTree(dir string, found map[string]bool) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			if found != nil && isRelevant(path) {
+				found[path] = true
+			}
+			return nil
+		}
+		if path != dir && skipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		if err := w.fsw.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// skipDir reports whether a directory is ignored by the go command.
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor"
+}
+
+// isRelevant reports whether a change to the file can affect loaded packages.
+func isRelevant(path string) bool {
+	switch filepath.Base(path) {
+	case "go.mod", "go.sum":
+		return true
+	}
+	return strings.HasSuffix(path, ".go")
+}
@@ -0,0 +1,103 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestWatcher_Run(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	changed := make(chan []string, 1)
+	w, err := New(dir, 50*time.Millisecond, func(files []string) {
+		changed <- files
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { w.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go w.Run(ctx)
+
+	// Files that cannot affect packages are ignored
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	// Files in new directories are reported
+	if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0o755); err != nil {
+		t.Fatalf("os.MkdirAll() error = %v", err)
+	}
+	goFile := filepath.Join(dir, "pkg", "pkg.go")
+	if err := os.WriteFile(goFile, []byte("package pkg\n"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	select {
+	case files := <-changed:
+		if !slices.Contains(files, goFile) {
+			t.Errorf("changed files = %v, want to contain %s", files, goFile)
+		}
+		if slices.Contains(files, filepath.Join(dir, "README.md")) {
+			t.Errorf("changed files = %v, want not to contain README.md", files)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change was reported")
+	}
+}
+
+func TestWatcher_Run_Overflow(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	goFile := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(goFile, []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	changed := make(chan []string, 1)
+	w, err := New(dir, 50*time.Millisecond, func(files []string) {
+		changed <- files
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { w.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	// Events were lost, so every file is reported and watching continues
+	w.fsw.Errors <- fsnotify.ErrEventOverflow
+	select {
+	case files := <-changed:
+		if !slices.Contains(files, goFile) {
+			t.Errorf("changed files after overflow = %v, want to contain %s", files, goFile)
+		}
+	case err := <-done:
+		t.Fatalf("Run() stopped after overflow: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change was reported after overflow")
+	}
+
+	if err := os.WriteFile(goFile, []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	select {
+	case files := <-changed:
+		if !slices.Contains(files, goFile) {
+			t.Errorf("changed files = %v, want to contain %s", files, goFile)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change was reported after overflow")
+	}
+}
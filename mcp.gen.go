@@ -18,45 +18,328 @@ type ServerPromptHandler interface {
 // ServerToolHandler is the interface for tool handlers.
 type ServerToolHandler interface {
 	HandleToolGolangListPackages(ctx context.Context, req *ToolGolangListPackagesRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangSearchDocs(ctx context.Context, req *ToolGolangSearchDocsRequest) (*mcp.CallToolResult, error)
 	HandleToolGolangInspectPackage(ctx context.Context, req *ToolGolangInspectPackageRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangGetDiagnostics(ctx context.Context, req *ToolGolangGetDiagnosticsRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangSearchSymbols(ctx context.Context, req *ToolGolangSearchSymbolsRequest) (*mcp.CallToolResult, error)
 	HandleToolGolangGetStructDoc(ctx context.Context, req *ToolGolangGetStructDocRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangGetInterfaceDoc(ctx context.Context, req *ToolGolangGetInterfaceDocRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangGetTypeDoc(ctx context.Context, req *ToolGolangGetTypeDocRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangFindImplementations(ctx context.Context, req *ToolGolangFindImplementationsRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangFindReferences(ctx context.Context, req *ToolGolangFindReferencesRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangGetCallers(ctx context.Context, req *ToolGolangGetCallersRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangGetCallees(ctx context.Context, req *ToolGolangGetCalleesRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangGetDependencyGraph(ctx context.Context, req *ToolGolangGetDependencyGraphRequest) (*mcp.CallToolResult, error)
 	HandleToolGolangGetFuncDoc(ctx context.Context, req *ToolGolangGetFuncDocRequest) (*mcp.CallToolResult, error)
 	HandleToolGolangGetMethodDoc(ctx context.Context, req *ToolGolangGetMethodDocRequest) (*mcp.CallToolResult, error)
+	HandleToolGolangGetSource(ctx context.Context, req *ToolGolangGetSourceRequest) (*mcp.CallToolResult, error)
 	HandleToolGolangGetConstAndVarDoc(ctx context.Context, req *ToolGolangGetConstAndVarDocRequest) (*mcp.CallToolResult, error)
 }
 
+// GolangListPackagesOutputFormatType represents possible values for output_format
+type GolangListPackagesOutputFormatType string
+
+const (
+	GolangListPackagesOutputFormatTypeJson     GolangListPackagesOutputFormatType = "json"
+	GolangListPackagesOutputFormatTypeMarkdown GolangListPackagesOutputFormatType = "markdown"
+)
+
 // ToolGolangListPackagesRequest contains input parameters for the golang_list_packages tool.
 type ToolGolangListPackagesRequest struct {
+	IncludeDependencies bool   `json:"include_dependencies,omitempty"`
+	OutputFormat        string `json:"output_format,omitempty"`
+}
+
+// GolangSearchDocsOutputFormatType represents possible values for output_format
+type GolangSearchDocsOutputFormatType string
+
+const (
+	GolangSearchDocsOutputFormatTypeJson     GolangSearchDocsOutputFormatType = "json"
+	GolangSearchDocsOutputFormatTypeMarkdown GolangSearchDocsOutputFormatType = "markdown"
+)
+
+// ToolGolangSearchDocsRequest contains input parameters for the golang_search_docs tool.
+type ToolGolangSearchDocsRequest struct {
+	Query        string `json:"query"`
+	Limit        int    `json:"limit,omitempty"`
+	OutputFormat string `json:"output_format,omitempty"`
 }
 
+// GolangInspectPackageOutputFormatType represents possible values for output_format
+type GolangInspectPackageOutputFormatType string
+
+const (
+	GolangInspectPackageOutputFormatTypeJson     GolangInspectPackageOutputFormatType = "json"
+	GolangInspectPackageOutputFormatTypeMarkdown GolangInspectPackageOutputFormatType = "markdown"
+)
+
 // ToolGolangInspectPackageRequest contains input parameters for the golang_inspect_package tool.
 type ToolGolangInspectPackageRequest struct {
 	PackageName     string `json:"package_name"`
 	IncludeComments bool   `json:"include_comments,omitempty"`
+	OutputFormat    string `json:"output_format,omitempty"`
+}
+
+// GolangGetDiagnosticsOutputFormatType represents possible values for output_format
+type GolangGetDiagnosticsOutputFormatType string
+
+const (
+	GolangGetDiagnosticsOutputFormatTypeJson     GolangGetDiagnosticsOutputFormatType = "json"
+	GolangGetDiagnosticsOutputFormatTypeMarkdown GolangGetDiagnosticsOutputFormatType = "markdown"
+)
+
+// ToolGolangGetDiagnosticsRequest contains input parameters for the golang_get_diagnostics tool.
+type ToolGolangGetDiagnosticsRequest struct {
+	PackageName         string `json:"package_name,omitempty"`
+	IncludeDependencies bool   `json:"include_dependencies,omitempty"`
+	OutputFormat        string `json:"output_format,omitempty"`
+}
+
+// GolangSearchSymbolsKindType represents possible values for kind
+type GolangSearchSymbolsKindType string
+
+const (
+	GolangSearchSymbolsKindTypeConst     GolangSearchSymbolsKindType = "const"
+	GolangSearchSymbolsKindTypeField     GolangSearchSymbolsKindType = "field"
+	GolangSearchSymbolsKindTypeFunc      GolangSearchSymbolsKindType = "func"
+	GolangSearchSymbolsKindTypeInterface GolangSearchSymbolsKindType = "interface"
+	GolangSearchSymbolsKindTypeMethod    GolangSearchSymbolsKindType = "method"
+	GolangSearchSymbolsKindTypeStruct    GolangSearchSymbolsKindType = "struct"
+	GolangSearchSymbolsKindTypeType      GolangSearchSymbolsKindType = "type"
+	GolangSearchSymbolsKindTypeVar       GolangSearchSymbolsKindType = "var"
+)
+
+// GolangSearchSymbolsOutputFormatType represents possible values for output_format
+type GolangSearchSymbolsOutputFormatType string
+
+const (
+	GolangSearchSymbolsOutputFormatTypeJson     GolangSearchSymbolsOutputFormatType = "json"
+	GolangSearchSymbolsOutputFormatTypeMarkdown GolangSearchSymbolsOutputFormatType = "markdown"
+)
+
+// ToolGolangSearchSymbolsRequest contains input parameters for the golang_search_symbols tool.
+type ToolGolangSearchSymbolsRequest struct {
+	Query        string `json:"query"`
+	Kind         string `json:"kind,omitempty"`
+	Limit        int    `json:"limit,omitempty"`
+	OutputFormat string `json:"output_format,omitempty"`
 }
 
+// GolangGetStructDocOutputFormatType represents possible values for output_format
+type GolangGetStructDocOutputFormatType string
+
+const (
+	GolangGetStructDocOutputFormatTypeJson     GolangGetStructDocOutputFormatType = "json"
+	GolangGetStructDocOutputFormatTypeMarkdown GolangGetStructDocOutputFormatType = "markdown"
+)
+
 // ToolGolangGetStructDocRequest contains input parameters for the golang_get_struct_doc tool.
 type ToolGolangGetStructDocRequest struct {
-	PackageName string `json:"package_name"`
-	StructName  string `json:"struct_name"`
+	PackageName   string `json:"package_name"`
+	StructName    string `json:"struct_name"`
+	IncludeLayout bool   `json:"include_layout,omitempty"`
+	OutputFormat  string `json:"output_format,omitempty"`
+}
+
+// GolangGetInterfaceDocOutputFormatType represents possible values for output_format
+type GolangGetInterfaceDocOutputFormatType string
+
+const (
+	GolangGetInterfaceDocOutputFormatTypeJson     GolangGetInterfaceDocOutputFormatType = "json"
+	GolangGetInterfaceDocOutputFormatTypeMarkdown GolangGetInterfaceDocOutputFormatType = "markdown"
+)
+
+// ToolGolangGetInterfaceDocRequest contains input parameters for the golang_get_interface_doc tool.
+type ToolGolangGetInterfaceDocRequest struct {
+	PackageName   string `json:"package_name"`
+	InterfaceName string `json:"interface_name"`
+	OutputFormat  string `json:"output_format,omitempty"`
+}
+
+// GolangGetTypeDocOutputFormatType represents possible values for output_format
+type GolangGetTypeDocOutputFormatType string
+
+const (
+	GolangGetTypeDocOutputFormatTypeJson     GolangGetTypeDocOutputFormatType = "json"
+	GolangGetTypeDocOutputFormatTypeMarkdown GolangGetTypeDocOutputFormatType = "markdown"
+)
+
+// ToolGolangGetTypeDocRequest contains input parameters for the golang_get_type_doc tool.
+type ToolGolangGetTypeDocRequest struct {
+	PackageName  string `json:"package_name"`
+	TypeName     string `json:"type_name"`
+	OutputFormat string `json:"output_format,omitempty"`
+}
+
+// GolangFindImplementationsOutputFormatType represents possible values for output_format
+type GolangFindImplementationsOutputFormatType string
+
+const (
+	GolangFindImplementationsOutputFormatTypeJson     GolangFindImplementationsOutputFormatType = "json"
+	GolangFindImplementationsOutputFormatTypeMarkdown GolangFindImplementationsOutputFormatType = "markdown"
+)
+
+// ToolGolangFindImplementationsRequest contains input parameters for the golang_find_implementations tool.
+type ToolGolangFindImplementationsRequest struct {
+	PackageName         string `json:"package_name"`
+	TypeName            string `json:"type_name"`
+	IncludeDependencies bool   `json:"include_dependencies,omitempty"`
+	OutputFormat        string `json:"output_format,omitempty"`
+}
+
+// GolangFindReferencesOutputFormatType represents possible values for output_format
+type GolangFindReferencesOutputFormatType string
+
+const (
+	GolangFindReferencesOutputFormatTypeJson     GolangFindReferencesOutputFormatType = "json"
+	GolangFindReferencesOutputFormatTypeMarkdown GolangFindReferencesOutputFormatType = "markdown"
+)
+
+// ToolGolangFindReferencesRequest contains input parameters for the golang_find_references tool.
+type ToolGolangFindReferencesRequest struct {
+	PackageName  string `json:"package_name"`
+	SymbolName   string `json:"symbol_name"`
+	OutputFormat string `json:"output_format,omitempty"`
+}
+
+// GolangGetCallersAlgorithmType represents possible values for algorithm
+type GolangGetCallersAlgorithmType string
+
+const (
+	GolangGetCallersAlgorithmTypeCha    GolangGetCallersAlgorithmType = "cha"
+	GolangGetCallersAlgorithmTypeStatic GolangGetCallersAlgorithmType = "static"
+	GolangGetCallersAlgorithmTypeVta    GolangGetCallersAlgorithmType = "vta"
+)
+
+// GolangGetCallersOutputFormatType represents possible values for output_format
+type GolangGetCallersOutputFormatType string
+
+const (
+	GolangGetCallersOutputFormatTypeJson     GolangGetCallersOutputFormatType = "json"
+	GolangGetCallersOutputFormatTypeMarkdown GolangGetCallersOutputFormatType = "markdown"
+)
+
+// ToolGolangGetCallersRequest contains input parameters for the golang_get_callers tool.
+type ToolGolangGetCallersRequest struct {
+	PackageName  string `json:"package_name"`
+	FunctionName string `json:"function_name"`
+	Depth        int    `json:"depth,omitempty"`
+	Algorithm    string `json:"algorithm,omitempty"`
+	OutputFormat string `json:"output_format,omitempty"`
+}
+
+// GolangGetCalleesAlgorithmType represents possible values for algorithm
+type GolangGetCalleesAlgorithmType string
+
+const (
+	GolangGetCalleesAlgorithmTypeCha    GolangGetCalleesAlgorithmType = "cha"
+	GolangGetCalleesAlgorithmTypeStatic GolangGetCalleesAlgorithmType = "static"
+	GolangGetCalleesAlgorithmTypeVta    GolangGetCalleesAlgorithmType = "vta"
+)
+
+// GolangGetCalleesOutputFormatType represents possible values for output_format
+type GolangGetCalleesOutputFormatType string
+
+const (
+	GolangGetCalleesOutputFormatTypeJson     GolangGetCalleesOutputFormatType = "json"
+	GolangGetCalleesOutputFormatTypeMarkdown GolangGetCalleesOutputFormatType = "markdown"
+)
+
+// ToolGolangGetCalleesRequest contains input parameters for the golang_get_callees tool.
+type ToolGolangGetCalleesRequest struct {
+	PackageName  string `json:"package_name"`
+	FunctionName string `json:"function_name"`
+	Depth        int    `json:"depth,omitempty"`
+	Algorithm    string `json:"algorithm,omitempty"`
+	OutputFormat string `json:"output_format,omitempty"`
+}
+
+// GolangGetDependencyGraphDiagramType represents possible values for diagram
+type GolangGetDependencyGraphDiagramType string
+
+const (
+	GolangGetDependencyGraphDiagramTypeDot     GolangGetDependencyGraphDiagramType = "dot"
+	GolangGetDependencyGraphDiagramTypeMermaid GolangGetDependencyGraphDiagramType = "mermaid"
+)
+
+// GolangGetDependencyGraphOutputFormatType represents possible values for output_format
+type GolangGetDependencyGraphOutputFormatType string
+
+const (
+	GolangGetDependencyGraphOutputFormatTypeJson     GolangGetDependencyGraphOutputFormatType = "json"
+	GolangGetDependencyGraphOutputFormatTypeMarkdown GolangGetDependencyGraphOutputFormatType = "markdown"
+)
+
+// ToolGolangGetDependencyGraphRequest contains input parameters for the golang_get_dependency_graph tool.
+type ToolGolangGetDependencyGraphRequest struct {
+	PackageName         string `json:"package_name,omitempty"`
+	Transitive          bool   `json:"transitive,omitempty"`
+	IncludeDependencies bool   `json:"include_dependencies,omitempty"`
+	TargetPackage       string `json:"target_package,omitempty"`
+	Diagram             string `json:"diagram,omitempty"`
+	OutputFormat        string `json:"output_format,omitempty"`
 }
 
+// GolangGetFuncDocOutputFormatType represents possible values for output_format
+type GolangGetFuncDocOutputFormatType string
+
+const (
+	GolangGetFuncDocOutputFormatTypeJson     GolangGetFuncDocOutputFormatType = "json"
+	GolangGetFuncDocOutputFormatTypeMarkdown GolangGetFuncDocOutputFormatType = "markdown"
+)
+
 // ToolGolangGetFuncDocRequest contains input parameters for the golang_get_func_doc tool.
 type ToolGolangGetFuncDocRequest struct {
-	PackageName string `json:"package_name"`
-	FuncName    string `json:"func_name"`
+	PackageName  string `json:"package_name"`
+	FuncName     string `json:"func_name"`
+	OutputFormat string `json:"output_format,omitempty"`
 }
 
+// GolangGetMethodDocOutputFormatType represents possible values for output_format
+type GolangGetMethodDocOutputFormatType string
+
+const (
+	GolangGetMethodDocOutputFormatTypeJson     GolangGetMethodDocOutputFormatType = "json"
+	GolangGetMethodDocOutputFormatTypeMarkdown GolangGetMethodDocOutputFormatType = "markdown"
+)
+
 // ToolGolangGetMethodDocRequest contains input parameters for the golang_get_method_doc tool.
 type ToolGolangGetMethodDocRequest struct {
-	PackageName string `json:"package_name"`
-	StructName  string `json:"struct_name"`
-	MethodName  string `json:"method_name"`
+	PackageName  string `json:"package_name"`
+	StructName   string `json:"struct_name"`
+	MethodName   string `json:"method_name"`
+	OutputFormat string `json:"output_format,omitempty"`
 }
 
+// GolangGetSourceOutputFormatType represents possible values for output_format
+type GolangGetSourceOutputFormatType string
+
+const (
+	GolangGetSourceOutputFormatTypeJson     GolangGetSourceOutputFormatType = "json"
+	GolangGetSourceOutputFormatTypeMarkdown GolangGetSourceOutputFormatType = "markdown"
+)
+
+// ToolGolangGetSourceRequest contains input parameters for the golang_get_source tool.
+type ToolGolangGetSourceRequest struct {
+	PackageName    string `json:"package_name"`
+	SymbolName     string `json:"symbol_name"`
+	ContextLines   int    `json:"context_lines,omitempty"`
+	IncludeComment bool   `json:"include_comment,omitempty"`
+	OutputFormat   string `json:"output_format,omitempty"`
+}
+
+// GolangGetConstAndVarDocOutputFormatType represents possible values for output_format
+type GolangGetConstAndVarDocOutputFormatType string
+
+const (
+	GolangGetConstAndVarDocOutputFormatTypeJson     GolangGetConstAndVarDocOutputFormatType = "json"
+	GolangGetConstAndVarDocOutputFormatTypeMarkdown GolangGetConstAndVarDocOutputFormatType = "markdown"
+)
+
 // ToolGolangGetConstAndVarDocRequest contains input parameters for the golang_get_const_and_var_doc tool.
 type ToolGolangGetConstAndVarDocRequest struct {
-	PackageName string `json:"package_name"`
+	PackageName  string `json:"package_name"`
+	OutputFormat string `json:"output_format,omitempty"`
 }
 
 // PromptList contains all available prompts.
@@ -64,31 +347,92 @@ var PromptList = []protocol.Prompt{}
 
 // JSON Schema type definitions generated from inputSchema
 var (
-	ToolGolangListPackagesInputSchema      = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{},"additionalProperties":false,"type":"object"}`)
-	ToolGolangInspectPackageInputSchema    = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name"},"include_comments":{"type":"boolean","description":"Whether to include comments","default":true}},"additionalProperties":false,"type":"object","required":["package_name"]}`)
-	ToolGolangGetStructDocInputSchema      = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the struct is defined"},"struct_name":{"type":"string","description":"Name of the struct"}},"additionalProperties":false,"type":"object","required":["package_name","struct_name"]}`)
-	ToolGolangGetFuncDocInputSchema        = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the function is defined"},"func_name":{"type":"string","description":"Name of the function"}},"additionalProperties":false,"type":"object","required":["package_name","func_name"]}`)
-	ToolGolangGetMethodDocInputSchema      = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the method is defined"},"struct_name":{"type":"string","description":"Name of the struct that owns the method"},"method_name":{"type":"string","description":"Name of the method"}},"additionalProperties":false,"type":"object","required":["package_name","struct_name","method_name"]}`)
-	ToolGolangGetConstAndVarDocInputSchema = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name"}},"additionalProperties":false,"type":"object","required":["package_name"]}`)
+	ToolGolangListPackagesInputSchema        = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"include_dependencies":{"type":"boolean","description":"Whether to also list the dependencies of the loaded packages","default":false},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object"}`)
+	ToolGolangSearchDocsInputSchema          = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"query":{"type":"string","description":"Words or phrase to search for in doc comments"},"limit":{"type":"integer","description":"Maximum number of results","default":20},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["query"]}`)
+	ToolGolangInspectPackageInputSchema      = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name"},"include_comments":{"type":"boolean","description":"Whether to include comments","default":true},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name"]}`)
+	ToolGolangGetDiagnosticsInputSchema      = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package to list the errors of. Defaults to all packages under the root directory"},"include_dependencies":{"type":"boolean","description":"Whether to also list the errors of dependencies","default":false},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object"}`)
+	ToolGolangSearchSymbolsInputSchema       = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"query":{"type":"string","description":"Name or part of the name to search for"},"kind":{"type":"string","enum":["func","method","struct","interface","type","const","var","field"],"description":"Only return symbols of this kind"},"limit":{"type":"integer","description":"Maximum number of results","default":20},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["query"]}`)
+	ToolGolangGetStructDocInputSchema        = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the struct is defined"},"struct_name":{"type":"string","description":"Name of the struct"},"include_layout":{"type":"boolean","description":"Whether to include the size and alignment of the struct and the offset of each field for the configured GOARCH","default":false},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","struct_name"]}`)
+	ToolGolangGetInterfaceDocInputSchema     = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the interface is defined"},"interface_name":{"type":"string","description":"Name of the interface"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","interface_name"]}`)
+	ToolGolangGetTypeDocInputSchema          = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the type is defined"},"type_name":{"type":"string","description":"Name of the type"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","type_name"]}`)
+	ToolGolangFindImplementationsInputSchema = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the type is defined"},"type_name":{"type":"string","description":"Name of the type or interface"},"include_dependencies":{"type":"boolean","description":"Whether to also search the dependencies of the loaded packages","default":false},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","type_name"]}`)
+	ToolGolangFindReferencesInputSchema      = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the symbol is defined"},"symbol_name":{"type":"string","description":"Name of the function or type or constant or variable. Use Type.Name for methods and fields"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","symbol_name"]}`)
+	ToolGolangGetCallersInputSchema          = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the function is defined"},"function_name":{"type":"string","description":"Name of the function. Use Type.Method for methods"},"depth":{"type":"integer","description":"Number of caller levels to follow (up to 5)","default":1},"algorithm":{"type":"string","enum":["static","cha","vta"],"description":"Call graph algorithm: static for static calls only; cha to resolve dynamic calls to every implementation; vta to resolve them to the types that flow to them","default":"cha"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","function_name"]}`)
+	ToolGolangGetCalleesInputSchema          = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the function is defined"},"function_name":{"type":"string","description":"Name of the function. Use Type.Method for methods"},"depth":{"type":"integer","description":"Number of callee levels to follow (up to 5)","default":1},"algorithm":{"type":"string","enum":["static","cha","vta"],"description":"Call graph algorithm: static for static calls only; cha to resolve dynamic calls to every implementation; vta to resolve them to the types that flow to them","default":"cha"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","function_name"]}`)
+	ToolGolangGetDependencyGraphInputSchema  = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package to start the graph from. Defaults to all packages under the root directory"},"transitive":{"type":"boolean","description":"Whether to follow imports transitively instead of listing direct imports only","default":false},"include_dependencies":{"type":"boolean","description":"Whether to include packages outside the root directory such as the standard library","default":false},"target_package":{"type":"string","description":"Package to explain the dependency on with the shortest import chain from package_name"},"diagram":{"type":"string","enum":["mermaid","dot"],"description":"Diagram format of markdown results","default":"mermaid"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object"}`)
+	ToolGolangGetFuncDocInputSchema          = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the function is defined"},"func_name":{"type":"string","description":"Name of the function"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","func_name"]}`)
+	ToolGolangGetMethodDocInputSchema        = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the method is defined"},"struct_name":{"type":"string","description":"Name of the struct that owns the method"},"method_name":{"type":"string","description":"Name of the method"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","struct_name","method_name"]}`)
+	ToolGolangGetSourceInputSchema           = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name where the symbol is defined"},"symbol_name":{"type":"string","description":"Name of the function or type or constant or variable. Use Type.Name for methods and fields"},"context_lines":{"type":"integer","minimum":0,"description":"Number of surrounding lines to include before and after the declaration","default":0},"include_comment":{"type":"boolean","description":"Whether to include the doc comment","default":false},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name","symbol_name"]}`)
+	ToolGolangGetConstAndVarDocInputSchema   = json.RawMessage(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"package_name":{"type":"string","description":"Package name"},"output_format":{"type":"string","enum":["markdown","json"],"description":"Format of the result. JSON results are also returned as structured content. Defaults to the server setting"}},"additionalProperties":false,"type":"object","required":["package_name"]}`)
 )
 
 // ToolList contains all available tools.
 var ToolList = []protocol.Tool{
 	{
 		Name:        "golang_list_packages",
-		Description: "Display a list of Go packages and their package comments. You can check the description and purpose of each package.",
+		Description: "Display a list of Go packages and their package comments. You can check the description and purpose of each package, and the module it belongs to when several modules are served. Dependencies, including the standard library, can be listed as well and are marked as such. Every tool that takes a package name also accepts dependency packages.",
 		InputSchema: ToolGolangListPackagesInputSchema,
 	},
+	{
+		Name:        "golang_search_docs",
+		Description: "Search the doc comments of all loaded Go packages with a natural-language query such as \"retry with backoff\". Matching symbols are ranked by relevance and returned with a snippet of their comment. You can find symbols by what they do when you don't know their names.",
+		InputSchema: ToolGolangSearchDocsInputSchema,
+	},
 	{
 		Name:        "golang_inspect_package",
-		Description: "List publicly available structs, methods, and functions in the specified Go package. You can check comments for each element.",
+		Description: "List publicly available constants, variables, functions, and types in the specified Go package, grouped the way go doc groups them: typed constants and variables, constructors, and methods are listed under their type. You can check comments for each element.",
 		InputSchema: ToolGolangInspectPackageInputSchema,
 	},
+	{
+		Name:        "golang_get_diagnostics",
+		Description: "List the errors reported while loading and type-checking Go packages. Documentation from a package with errors may be incomplete, so check here when a declaration is unexpectedly missing.",
+		InputSchema: ToolGolangGetDiagnosticsInputSchema,
+	},
+	{
+		Name:        "golang_search_symbols",
+		Description: "Search Go symbols by name across all loaded packages. Package-level identifiers, methods, and fields are matched by prefix, substring, or fuzzy match and ranked by match quality. You can discover symbols without knowing their exact package or name.",
+		InputSchema: ToolGolangSearchSymbolsInputSchema,
+	},
 	{
 		Name:        "golang_get_struct_doc",
-		Description: "Display detailed information about the specified Go struct. You can check the struct's comments, fields, methods, and their comments.",
+		Description: "Display detailed information about the specified Go struct. You can check the struct's comments, fields with their struct tags, and method set. Embedded fields are flagged, and methods promoted from embedded fields are reported with the type they come from and whether they need a pointer receiver.",
 		InputSchema: ToolGolangGetStructDocInputSchema,
 	},
+	{
+		Name:        "golang_get_interface_doc",
+		Description: "Display detailed information about the specified Go interface. You can check the interface's comments, its method set including embedded interfaces, the type set of constraint interfaces, and the types that implement it.",
+		InputSchema: ToolGolangGetInterfaceDocInputSchema,
+	},
+	{
+		Name:        "golang_get_type_doc",
+		Description: "Display detailed information about the specified Go named type or type alias of any kind, such as `type Mode int`, `type HandlerFunc func(...)`, or `type IDs []string`. You can check the type's underlying type, comments, methods, constants of the type such as iota enumerations, and the functions that construct it.",
+		InputSchema: ToolGolangGetTypeDocInputSchema,
+	},
+	{
+		Name:        "golang_find_implementations",
+		Description: "Find implementation relationships of the specified Go type. For an interface, list the types that implement it. For any other type, list the interfaces it implements. Results include package paths and source positions.",
+		InputSchema: ToolGolangFindImplementationsInputSchema,
+	},
+	{
+		Name:        "golang_find_references",
+		Description: "Find every reference to the specified Go package-level symbol, method, or field in the loaded packages and their test files. Each reference includes its file, line, column, and enclosing function, so you can review the impact of a change without searching the text.",
+		InputSchema: ToolGolangFindReferencesInputSchema,
+	},
+	{
+		Name:        "golang_get_callers",
+		Description: "List the calls to the specified Go function or method, with call site positions. Callers of callers are followed up to the requested depth through the loaded packages. Calls through interfaces and function values are resolved with the selected call graph algorithm.",
+		InputSchema: ToolGolangGetCallersInputSchema,
+	},
+	{
+		Name:        "golang_get_callees",
+		Description: "List the calls made by the specified Go function or method, with call site positions. Callees of callees are followed up to the requested depth through the loaded packages. Calls through interfaces and function values are resolved with the selected call graph algorithm.",
+		InputSchema: ToolGolangGetCalleesInputSchema,
+	},
+	{
+		Name:        "golang_get_dependency_graph",
+		Description: "Display the import graph of a Go package, or of all loaded packages, as a Mermaid or DOT diagram, or as JSON. Import cycles and violations of the configured layering rules are reported, and with target_package the shortest import chain explains why the package depends on it.",
+		InputSchema: ToolGolangGetDependencyGraphInputSchema,
+	},
 	{
 		Name:        "golang_get_func_doc",
 		Description: "Display detailed information about the specified Go function. You can check the function's signature, comments, and usage examples.",
@@ -99,6 +443,11 @@ var ToolList = []protocol.Tool{
 		Description: "Display detailed information about the specified Go struct method. You can check the method's signature, comments, and usage examples.",
 		InputSchema: ToolGolangGetMethodDocInputSchema,
 	},
+	{
+		Name:        "golang_get_source",
+		Description: "Display the source code of the specified Go declaration with its file path and line range. Functions and methods are shown with their bodies, and constants and variables with their whole declaration block. You can read the implementation, not just the documentation.",
+		InputSchema: ToolGolangGetSourceInputSchema,
+	},
 	{
 		Name:        "golang_get_const_and_var_doc",
 		Description: "Display detailed information about constants and variables in the specified Go package. You can check the type, value, and comments for each constant and variable.",
@@ -138,6 +487,16 @@ func NewHandler(toolHandler ServerToolHandler) *mcp.Handler {
 					return nil, err
 				}
 				return toolHandler.HandleToolGolangListPackages(ctx, &in)
+			case "golang_search_docs":
+				var in ToolGolangSearchDocsRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangSearchDocs(ctx, &in)
 			case "golang_inspect_package":
 				var in ToolGolangInspectPackageRequest
 				if err := json.Unmarshal(req.Arguments, &in); err != nil {
@@ -148,6 +507,26 @@ func NewHandler(toolHandler ServerToolHandler) *mcp.Handler {
 					return nil, err
 				}
 				return toolHandler.HandleToolGolangInspectPackage(ctx, &in)
+			case "golang_get_diagnostics":
+				var in ToolGolangGetDiagnosticsRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangGetDiagnostics(ctx, &in)
+			case "golang_search_symbols":
+				var in ToolGolangSearchSymbolsRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangSearchSymbols(ctx, &in)
 			case "golang_get_struct_doc":
 				var in ToolGolangGetStructDocRequest
 				if err := json.Unmarshal(req.Arguments, &in); err != nil {
@@ -158,6 +537,76 @@ func NewHandler(toolHandler ServerToolHandler) *mcp.Handler {
 					return nil, err
 				}
 				return toolHandler.HandleToolGolangGetStructDoc(ctx, &in)
+			case "golang_get_interface_doc":
+				var in ToolGolangGetInterfaceDocRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangGetInterfaceDoc(ctx, &in)
+			case "golang_get_type_doc":
+				var in ToolGolangGetTypeDocRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangGetTypeDoc(ctx, &in)
+			case "golang_find_implementations":
+				var in ToolGolangFindImplementationsRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangFindImplementations(ctx, &in)
+			case "golang_find_references":
+				var in ToolGolangFindReferencesRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangFindReferences(ctx, &in)
+			case "golang_get_callers":
+				var in ToolGolangGetCallersRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangGetCallers(ctx, &in)
+			case "golang_get_callees":
+				var in ToolGolangGetCalleesRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangGetCallees(ctx, &in)
+			case "golang_get_dependency_graph":
+				var in ToolGolangGetDependencyGraphRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangGetDependencyGraph(ctx, &in)
 			case "golang_get_func_doc":
 				var in ToolGolangGetFuncDocRequest
 				if err := json.Unmarshal(req.Arguments, &in); err != nil {
@@ -178,6 +627,16 @@ func NewHandler(toolHandler ServerToolHandler) *mcp.Handler {
 					return nil, err
 				}
 				return toolHandler.HandleToolGolangGetMethodDoc(ctx, &in)
+			case "golang_get_source":
+				var in ToolGolangGetSourceRequest
+				if err := json.Unmarshal(req.Arguments, &in); err != nil {
+					return nil, err
+				}
+				inputSchema, _ := ToolList[idx].InputSchema.(json.RawMessage)
+				if err := protocol.ValidateByJSONSchema(string(inputSchema), in); err != nil {
+					return nil, err
+				}
+				return toolHandler.HandleToolGolangGetSource(ctx, &in)
 			case "golang_get_const_and_var_doc":
 				var in ToolGolangGetConstAndVarDocRequest
 				if err := json.Unmarshal(req.Arguments, &in); err != nil {